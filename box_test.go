@@ -22,6 +22,32 @@ func TestBoxSeal(t *testing.T) {
 	require.Equal(t, "Hey bob, it's alice. The passcode is 12345.", string(out))
 }
 
+func TestBoxOpenErrors(t *testing.T) {
+	alice := keys.GenerateX25519Key()
+	bob := keys.GenerateX25519Key()
+	charlie := keys.GenerateX25519Key()
+
+	encrypted := keys.BoxSeal([]byte("hi bob"), bob.PublicKey(), alice)
+
+	// Tampered
+	tampered := append([]byte{}, encrypted...)
+	tampered[len(tampered)-1] ^= 0x01
+	_, err := keys.BoxOpen(tampered, alice.PublicKey(), bob)
+	require.EqualError(t, err, "box open failed")
+
+	// Wrong sender
+	_, err = keys.BoxOpen(encrypted, charlie.PublicKey(), bob)
+	require.EqualError(t, err, "box open failed")
+
+	// Wrong recipient
+	_, err = keys.BoxOpen(encrypted, alice.PublicKey(), charlie)
+	require.EqualError(t, err, "box open failed")
+
+	// Too short
+	_, err = keys.BoxOpen(encrypted[:23], alice.PublicKey(), bob)
+	require.EqualError(t, err, "not enough bytes")
+}
+
 func ExampleBoxSeal() {
 	ak := keys.GenerateX25519Key()
 	bk := keys.GenerateX25519Key()
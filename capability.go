@@ -0,0 +1,90 @@
+package keys
+
+import (
+	"encoding/json"
+	"time"
+
+	"github.com/keys-pub/keys/encoding"
+	"github.com/keys-pub/keys/tsutil"
+	"github.com/pkg/errors"
+	"golang.org/x/crypto/nacl/sign"
+)
+
+// ErrCapabilityExpired if a capability token has expired.
+var ErrCapabilityExpired = errors.New("capability expired")
+
+// capabilityContext prefixes the bytes signed for a capability token, so a
+// signature made for something else (for example with Sign) isn't accepted as
+// a capability, and vice versa.
+const capabilityContext = "keys.pub/capability\x00"
+
+// capabilityMaxSkew is how far in the future a capability's issued time can
+// be, for clock differences.
+const capabilityMaxSkew = time.Minute
+
+type capability struct {
+	Expire int64  `json:"exp"`
+	Issued int64  `json:"iat"`
+	Scope  string `json:"scope"`
+}
+
+// MintCapability creates a (bearer) capability token for scope, signed by key,
+// valid from time now for ttl.
+// The token is the Base62 encoded signature and JSON message with the scope,
+// issued and expiry time (in milliseconds). The signature is over the message
+// prefixed with a capability context.
+func MintCapability(key *EdX25519Key, scope string, ttl time.Duration, now time.Time) (string, error) {
+	if scope == "" {
+		return "", errors.Errorf("empty capability scope")
+	}
+	if ttl <= 0 {
+		return "", errors.Errorf("invalid capability ttl")
+	}
+	b, err := json.Marshal(capability{
+		Expire: tsutil.Millis(now.Add(ttl)),
+		Issued: tsutil.Millis(now),
+		Scope:  scope,
+	})
+	if err != nil {
+		return "", err
+	}
+	sig := key.SignDetached(capabilityBytes(b))
+	return encoding.MustEncode(bytesJoin(sig, b), encoding.Base62), nil
+}
+
+// VerifyCapability verifies a capability token from MintCapability was signed
+// by the public key and hasn't expired at time now, returning the scope.
+// Returns ErrCapabilityExpired if expired.
+// A token issued more than a minute after now is invalid, so a token can't be
+// pre-dated to outlive a key rotation.
+func VerifyCapability(token string, pk *EdX25519PublicKey, now time.Time) (string, error) {
+	b, err := encoding.Decode(token, encoding.Base62)
+	if err != nil {
+		return "", errors.Wrapf(err, "invalid capability")
+	}
+	if len(b) <= sign.Overhead {
+		return "", errors.Errorf("invalid capability")
+	}
+	sig, out := b[:sign.Overhead], b[sign.Overhead:]
+	if err := pk.VerifyDetached(sig, capabilityBytes(out)); err != nil {
+		return "", err
+	}
+	var c capability
+	if err := json.Unmarshal(out, &c); err != nil {
+		return "", errors.Wrapf(err, "invalid capability")
+	}
+	if c.Scope == "" {
+		return "", errors.Errorf("invalid capability: empty scope")
+	}
+	if c.Issued > tsutil.Millis(now.Add(capabilityMaxSkew)) {
+		return "", errors.Errorf("invalid capability: issued in the future")
+	}
+	if tsutil.Millis(now) >= c.Expire {
+		return "", ErrCapabilityExpired
+	}
+	return c.Scope, nil
+}
+
+func capabilityBytes(b []byte) []byte {
+	return bytesJoin([]byte(capabilityContext), b)
+}
@@ -0,0 +1,71 @@
+package keys_test
+
+import (
+	"encoding/json"
+	"testing"
+	"time"
+
+	"github.com/keys-pub/keys"
+	"github.com/keys-pub/keys/encoding"
+	"github.com/keys-pub/keys/tsutil"
+	"github.com/stretchr/testify/require"
+)
+
+func TestCapability(t *testing.T) {
+	clock := tsutil.NewTestClock()
+	alice := keys.NewEdX25519KeyFromSeed(testSeed(0x01))
+
+	token, err := keys.MintCapability(alice, "read:/files", time.Minute, clock.Now())
+	require.NoError(t, err)
+
+	scope, err := keys.VerifyCapability(token, alice.PublicKey(), clock.Now())
+	require.NoError(t, err)
+	require.Equal(t, "read:/files", scope)
+
+	// Different key
+	bob := keys.NewEdX25519KeyFromSeed(testSeed(0x02))
+	_, err = keys.VerifyCapability(token, bob.PublicKey(), clock.Now())
+	require.EqualError(t, err, "verify failed")
+
+	// Tampered
+	b, err := encoding.Decode(token, encoding.Base62)
+	require.NoError(t, err)
+	b[len(b)-2] ^= 0x01
+	_, err = keys.VerifyCapability(encoding.MustEncode(b, encoding.Base62), alice.PublicKey(), clock.Now())
+	require.EqualError(t, err, "verify failed")
+
+	// Signed (without the capability context) isn't a capability
+	b, err = json.Marshal(map[string]interface{}{
+		"exp":   tsutil.Millis(clock.Now().Add(time.Hour)),
+		"iat":   tsutil.Millis(clock.Now()),
+		"scope": "read:/files",
+	})
+	require.NoError(t, err)
+	_, err = keys.VerifyCapability(encoding.MustEncode(alice.Sign(b), encoding.Base62), alice.PublicKey(), clock.Now())
+	require.EqualError(t, err, "verify failed")
+
+	// Issued in the future
+	future, err := keys.MintCapability(alice, "read:/files", time.Hour, clock.Now().Add(2*time.Minute))
+	require.NoError(t, err)
+	_, err = keys.VerifyCapability(future, alice.PublicKey(), clock.Now())
+	require.EqualError(t, err, "invalid capability: issued in the future")
+	// Within the allowed clock skew
+	skewed, err := keys.MintCapability(alice, "read:/files", time.Hour, clock.Now().Add(30*time.Second))
+	require.NoError(t, err)
+	_, err = keys.VerifyCapability(skewed, alice.PublicKey(), clock.Now())
+	require.NoError(t, err)
+
+	// Too short
+	_, err = keys.VerifyCapability(encoding.MustEncode(make([]byte, 64), encoding.Base62), alice.PublicKey(), clock.Now())
+	require.EqualError(t, err, "invalid capability")
+
+	// Expired
+	clock.Add(time.Minute)
+	_, err = keys.VerifyCapability(token, alice.PublicKey(), clock.Now())
+	require.Equal(t, keys.ErrCapabilityExpired, err)
+
+	_, err = keys.MintCapability(alice, "", time.Minute, clock.Now())
+	require.EqualError(t, err, "empty capability scope")
+	_, err = keys.MintCapability(alice, "read", 0, clock.Now())
+	require.EqualError(t, err, "invalid capability ttl")
+}
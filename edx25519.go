@@ -3,6 +3,7 @@ package keys
 import (
 	"crypto"
 	"crypto/ed25519"
+	"crypto/sha256"
 	"crypto/subtle"
 
 	"github.com/keys-pub/keys/encoding"
@@ -204,6 +205,11 @@ func (k *EdX25519PublicKey) Private() []byte {
 	return nil
 }
 
+// Equal returns true if equal to key (in constant time).
+func (k *EdX25519PublicKey) Equal(o *EdX25519PublicKey) bool {
+	return subtle.ConstantTimeCompare(k.Bytes(), o.Bytes()) == 1
+}
+
 // X25519PublicKey converts the ed25519 public key to a x25519 public key.
 func (k *EdX25519PublicKey) X25519PublicKey() *X25519PublicKey {
 	edpk := ed25519.PublicKey(k.publicKey[:])
@@ -250,6 +256,16 @@ func NewEdX25519KeyFromSeed(seed *[ed25519.SeedSize]byte) *EdX25519Key {
 	return NewEdX25519KeyFromPrivateKey(Bytes64(privateKey))
 }
 
+// NewEdX25519KeyFromString constructs a deterministic EdX25519Key from a
+// string, using the SHA-256 hash of the string as the seed.
+// This is for test fixtures or simple derivation, for example,
+// NewEdX25519KeyFromString("alice"); it is NOT suitable for deriving keys from
+// passwords, see KeyForPassword.
+func NewEdX25519KeyFromString(s string) *EdX25519Key {
+	seed := sha256.Sum256([]byte(s))
+	return NewEdX25519KeyFromSeed(&seed)
+}
+
 // Seed returns information on how to generate this key from ed25519 package seed.
 func (k *EdX25519Key) Seed() *[ed25519.SeedSize]byte {
 	pk := ed25519.PrivateKey(k.privateKey[:])
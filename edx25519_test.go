@@ -23,6 +23,17 @@ func TestEdX25519KeySeed(t *testing.T) {
 	require.False(t, sk.Equal(sk2))
 }
 
+func TestNewEdX25519KeyFromString(t *testing.T) {
+	alice := keys.NewEdX25519KeyFromString("alice")
+	require.Equal(t, keys.ID("kex16kl5507vuutmqwytesn5n67pfzkej6dj8az7uxmqtl2cw7zhdtzqadg8dw"), alice.ID())
+	alice2 := keys.NewEdX25519KeyFromString("alice")
+	require.Equal(t, alice.ID(), alice2.ID())
+	require.True(t, alice.Equal(alice2))
+
+	bob := keys.NewEdX25519KeyFromString("bob")
+	require.NotEqual(t, alice.ID(), bob.ID())
+}
+
 func TestEdX25519KeySignVerify(t *testing.T) {
 	signKey := keys.GenerateEdX25519Key()
 
@@ -133,3 +144,71 @@ func TestEdX25519JSON(t *testing.T) {
 	require.Equal(t, key.Private(), out.Key.Private())
 	require.Equal(t, key.Public(), out.Key.Public())
 }
+
+func TestEdX25519RFC8032(t *testing.T) {
+	// Test vectors from RFC 8032, section 7.1.
+	vectors := []struct {
+		seed string
+		pub  string
+		msg  string
+		sig  string
+	}{
+		{
+			seed: "9d61b19deffd5a60ba844af492ec2cc44449c5697b326919703bac031cae7f60",
+			pub:  "d75a980182b10ab7d54bfed3c964073a0ee172f3daa62325af021a68f707511a",
+			msg:  "",
+			sig: "e5564300c360ac729086e2cc806e828a84877f1eb8e5d974d873e06522490155" +
+				"5fb8821590a33bacc61e39701cf9b46bd25bf5f0595bbe24655141438e7a100b",
+		},
+		{
+			seed: "4ccd089b28ff96da9db6c346ec114e0f5b8a319f35aba624da8cf6ed4fb8a6fb",
+			pub:  "3d4017c3e843895a92b70aa74d1b7ebc9c982ccf2ec4968cc0cd55f12af4660c",
+			msg:  "72",
+			sig: "92a009a9f0d4cab8720e820b5f642540a2b27b5416503f8fb3762223ebdb69da" +
+				"085ac1e43e15996e458f3613d0f11d8c387b2eaeb4302aeeb00d291612bb0c00",
+		},
+		{
+			seed: "c5aa8df43f9f837bedb7442f31dcb7b166d38535076f094b85ce3a2e0b4458f7",
+			pub:  "fc51cd8e6218a1a38da47ed00230f0580816ed13ba3303ac5deb911548908025",
+			msg:  "af82",
+			sig: "6291d657deec24024827e69c3abe01a30ce548a284743a445e3680d7db5ac3ac" +
+				"18ff9b538d16f290ae67f760984dc6594a7c15e9716ed28dc027beceea1ec40a",
+		},
+	}
+
+	for _, v := range vectors {
+		key := keys.NewEdX25519KeyFromSeed(keys.Bytes32(encoding.MustDecode(v.seed, encoding.Hex)))
+		pub := encoding.MustDecode(v.pub, encoding.Hex)
+		msg := encoding.MustDecode(v.msg, encoding.Hex)
+		sig := encoding.MustDecode(v.sig, encoding.Hex)
+		require.Equal(t, pub, key.PublicKey().Bytes())
+
+		// Detached
+		require.Equal(t, sig, key.SignDetached(msg))
+		err := key.PublicKey().VerifyDetached(sig, msg)
+		if len(msg) == 0 {
+			// VerifyDetached requires a message.
+			require.EqualError(t, err, "no bytes")
+		} else {
+			require.NoError(t, err)
+		}
+
+		// Attached (signature prepended to the message)
+		signed := key.Sign(msg)
+		require.Equal(t, append(sig, msg...), signed)
+		out, err := key.PublicKey().Verify(signed)
+		require.NoError(t, err)
+		require.Equal(t, msg, out)
+	}
+}
+
+func TestEdX25519PublicKeyEqual(t *testing.T) {
+	alice := keys.NewEdX25519KeyFromSeed(testSeed(0x01))
+	bob := keys.NewEdX25519KeyFromSeed(testSeed(0x02))
+	require.True(t, alice.PublicKey().Equal(keys.NewEdX25519KeyFromSeed(testSeed(0x01)).PublicKey()))
+	require.False(t, alice.PublicKey().Equal(bob.PublicKey()))
+
+	require.True(t, keys.EqualIDs(alice.ID(), keys.ID(alice.ID().String())))
+	require.False(t, keys.EqualIDs(alice.ID(), bob.ID()))
+	require.False(t, keys.EqualIDs(alice.ID(), ""))
+}
@@ -3,6 +3,7 @@ package encoding_test
 import (
 	"crypto/rand"
 	"encoding/hex"
+	"strings"
 	"testing"
 
 	"github.com/keys-pub/keys/encoding"
@@ -41,3 +42,26 @@ func TestPhraseFromKey(t *testing.T) {
 	require.NoError(t, err)
 	require.Equal(t, key, keyOut[:])
 }
+
+func TestPhraseInvalidWord(t *testing.T) {
+	key := randBytes(32)
+	phrase, err := encoding.BytesToPhrase(key)
+	require.NoError(t, err)
+	require.Equal(t, 24, len(strings.Fields(phrase)))
+	require.True(t, encoding.IsValidPhrase(phrase, false))
+
+	words := strings.Fields(phrase)
+	words[3] = "notaword"
+	invalid := strings.Join(words, " ")
+	require.False(t, encoding.IsValidPhrase(invalid, false))
+	_, err = encoding.PhraseToBytes(invalid, false)
+	require.EqualError(t, err, "invalid phrase")
+
+	// Sanitize
+	keyOut, err := encoding.PhraseToBytes("  "+strings.ToUpper(phrase)+"\n", true)
+	require.NoError(t, err)
+	require.Equal(t, key, keyOut[:])
+
+	_, err = encoding.BytesToPhrase(randBytes(31))
+	require.Equal(t, encoding.ErrInvalidBIP39Input, err)
+}
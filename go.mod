@@ -1,6 +1,6 @@
 module github.com/keys-pub/keys
 
-go 1.13
+go 1.20
 
 require (
 	github.com/ScaleFT/sshkeys v0.0.0-20200327173127-6142f742bca5
@@ -9,7 +9,6 @@ require (
 	github.com/dchest/blake2b v1.0.0
 	github.com/flynn/noise v0.0.0-20180327030543-2492fe189ae6
 	github.com/godbus/dbus v4.1.0+incompatible
-	github.com/golang/protobuf v1.4.2 // indirect
 	github.com/keybase/go-keychain v0.0.0-20200502122510-cda31fe0c86d
 	github.com/keybase/saltpack v0.0.0-20200430135328-e19b1910c0c5
 	github.com/keys-pub/secretservice v0.0.0-20200519003656-26e44b8df47f
@@ -18,6 +17,15 @@ require (
 	github.com/tyler-smith/go-bip39 v1.0.2
 	github.com/vmihailenco/msgpack/v4 v4.3.11
 	golang.org/x/crypto v0.0.0-20200510223506-06a226fb4e37
+)
+
+require (
+	github.com/dchest/bcrypt_pbkdf v0.0.0-20150205184540-83f37f9c154a // indirect
+	github.com/golang/protobuf v1.4.2 // indirect
+	github.com/keybase/go-codec v0.0.0-20180928230036-164397562123 // indirect
+	github.com/pmezard/go-difflib v1.0.0 // indirect
+	github.com/vmihailenco/tagparser v0.1.1 // indirect
 	golang.org/x/net v0.0.0-20200520182314-0ba52f642ac2 // indirect
 	golang.org/x/sys v0.0.0-20200523222454-059865788121 // indirect
+	gopkg.in/yaml.v2 v2.2.8 // indirect
 )
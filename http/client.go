@@ -5,14 +5,20 @@ package http
 import (
 	"context"
 	"fmt"
+	"io"
 	"io/ioutil"
 	"net"
 	"net/http"
 	"net/url"
 	"strings"
 	"time"
+
+	"github.com/pkg/errors"
 )
 
+// MaxResponseSize is the maximum size of a response body.
+const MaxResponseSize = 1024 * 1024
+
 // ErrTimeout is a timeout error.
 type ErrTimeout struct {
 	error
@@ -56,6 +62,11 @@ func httpClient() *http.Client {
 }
 
 func doRequest(client *http.Client, req *Request, headers []Header, options ...func(*http.Request)) (http.Header, []byte, error) {
+	return doRequestWithMax(client, req, headers, MaxResponseSize, options...)
+}
+
+// doRequestWithMax is doRequest with a maximum response size.
+func doRequestWithMax(client *http.Client, req *Request, headers []Header, maxSize int, options ...func(*http.Request)) (http.Header, []byte, error) {
 	logger.Debugf("Requesting %s %s", req.Method, req.URL)
 
 	req.Header.Set("User-Agent", "keys.pub")
@@ -99,10 +110,13 @@ func doRequest(client *http.Client, req *Request, headers []Header, options ...f
 		return resp.Header, nil, Error{StatusCode: resp.StatusCode}
 	}
 
-	respBody, err := ioutil.ReadAll(resp.Body)
+	respBody, err := ioutil.ReadAll(io.LimitReader(resp.Body, int64(maxSize)+1))
 	if err != nil {
 		return nil, nil, err
 	}
+	if len(respBody) > maxSize {
+		return nil, nil, errors.Errorf("response too large")
+	}
 	logger.Debugf("Response body (len=%d)", len(respBody))
 
 	return resp.Header, respBody, nil
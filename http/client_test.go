@@ -0,0 +1,33 @@
+package http_test
+
+import (
+	"bytes"
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	khttp "github.com/keys-pub/keys/http"
+	"github.com/stretchr/testify/require"
+)
+
+func TestRequestMaxResponseSize(t *testing.T) {
+	size := khttp.MaxResponseSize
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		_, _ = w.Write(bytes.Repeat([]byte{'a'}, size))
+	}))
+	defer server.Close()
+
+	client := khttp.NewClient()
+	req, err := khttp.NewRequest("GET", server.URL, nil)
+	require.NoError(t, err)
+	b, err := client.Request(context.TODO(), req, nil)
+	require.NoError(t, err)
+	require.Equal(t, khttp.MaxResponseSize, len(b))
+
+	size = khttp.MaxResponseSize + 1
+	req, err = khttp.NewRequest("GET", server.URL, nil)
+	require.NoError(t, err)
+	_, err = client.Request(context.TODO(), req, nil)
+	require.EqualError(t, err, "response too large")
+}
@@ -0,0 +1,297 @@
+package http
+
+import (
+	"bytes"
+	"encoding/json"
+	"io/ioutil"
+	"net/http"
+	"strconv"
+	"strings"
+	"sync"
+
+	"github.com/keys-pub/keys"
+	"github.com/pkg/errors"
+)
+
+const (
+	// sigchainPageLimit is the maximum number of statements in a sigchain
+	// response.
+	sigchainPageLimit = 100
+	// sigchainPageSize is the response size after which no more statements are
+	// added to a sigchain response (a response always has at least one).
+	sigchainPageSize = MaxResponseSize
+	// sigchainMaxSize is the maximum size of a sigchain response or (posted)
+	// statement, enough for a page plus a statement with data at the
+	// keys.DefaultSigchainLimits max (base64 encoded).
+	sigchainMaxSize = 4 * MaxResponseSize
+)
+
+// SigchainHandler serves sigchains from Sigchains over HTTP.
+//
+//	GET /sigchain/{kid}?from=seq&limit=n  returns a JSON array of statements after seq
+//	POST /sigchain/{kid}  adds a statement (request body) to the sigchain
+//
+// Statements are returned in pages, up to limit (at most 100) and about 1MiB;
+// request the next page from the last seq, until there are no more
+// statements.
+// Added statements are verified and must extend the stored sigchain. A
+// statement that doesn't (wrong seq or prev) is a 409 conflict, an invalid
+// statement is a 400.
+// Statements must not have a context, see SigchainHandlerWithContext.
+// Use RemoteSigchains as a client.
+func SigchainHandler(scs *keys.Sigchains) http.Handler {
+	return SigchainHandlerWithContext(scs, "")
+}
+
+// SigchainHandlerWithContext is SigchainHandler for sigchains with a context
+// (see keys.NewSigchainWithContext).
+// Statements with a different context are rejected.
+func SigchainHandlerWithContext(scs *keys.Sigchains, context string) http.Handler {
+	return &sigchainHandler{scs: scs, context: context}
+}
+
+type sigchainHandler struct {
+	scs     *keys.Sigchains
+	context string
+	// mtx so adds to a sigchain don't race.
+	mtx sync.Mutex
+}
+
+func (h *sigchainHandler) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	if !strings.HasPrefix(r.URL.Path, "/sigchain/") {
+		http.NotFound(w, r)
+		return
+	}
+	kid, err := keys.ParseID(strings.TrimPrefix(r.URL.Path, "/sigchain/"))
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+	switch r.Method {
+	case "GET":
+		h.get(w, r, kid)
+	case "POST":
+		h.post(w, r, kid)
+	default:
+		http.Error(w, "method not allowed", http.StatusMethodNotAllowed)
+	}
+}
+
+func (h *sigchainHandler) get(w http.ResponseWriter, r *http.Request, kid keys.ID) {
+	from := 0
+	if s := r.URL.Query().Get("from"); s != "" {
+		n, err := strconv.Atoi(s)
+		if err != nil || n < 0 {
+			http.Error(w, "invalid from", http.StatusBadRequest)
+			return
+		}
+		from = n
+	}
+	limit := sigchainPageLimit
+	if s := r.URL.Query().Get("limit"); s != "" {
+		n, err := strconv.Atoi(s)
+		if err != nil || n < 1 {
+			http.Error(w, "invalid limit", http.StatusBadRequest)
+			return
+		}
+		if n < limit {
+			limit = n
+		}
+	}
+	sc, err := h.scs.SigchainWithContext(kid, h.context)
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+		return
+	}
+	if sc.Length() == 0 {
+		http.NotFound(w, r)
+		return
+	}
+	page := []json.RawMessage{}
+	size := 0
+	for _, st := range sc.StatementsRange(from+1, sc.LastSeq()) {
+		b, err := st.MarshalJSON()
+		if err != nil {
+			http.Error(w, err.Error(), http.StatusInternalServerError)
+			return
+		}
+		if len(page) > 0 && size+len(b) > sigchainPageSize {
+			break
+		}
+		page = append(page, b)
+		size += len(b) + 1
+		if len(page) >= limit {
+			break
+		}
+	}
+	b, err := json.Marshal(page)
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+		return
+	}
+	w.Header().Set("Content-Type", "application/json")
+	_, _ = w.Write(b)
+}
+
+func (h *sigchainHandler) post(w http.ResponseWriter, r *http.Request, kid keys.ID) {
+	b, err := ioutil.ReadAll(http.MaxBytesReader(w, r.Body, sigchainMaxSize))
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+	// Unmarshal (instead of StatementFromBytes) so statements signed by a
+	// delegate are accepted, the delegation is checked by Add.
+	var st keys.Statement
+	if err := json.Unmarshal(b, &st); err != nil {
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+	if st.KID != kid {
+		http.Error(w, "invalid statement kid", http.StatusBadRequest)
+		return
+	}
+
+	h.mtx.Lock()
+	defer h.mtx.Unlock()
+	sc, err := h.scs.SigchainWithContext(kid, h.context)
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+		return
+	}
+	ok, err := extendsSigchain(sc, &st)
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+		return
+	}
+	if !ok {
+		http.Error(w, "statement doesn't extend sigchain", http.StatusConflict)
+		return
+	}
+	if err := sc.Add(&st); err != nil {
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+	// Only the new statement is saved, the rest are stored already.
+	if err := h.scs.SaveStatement(&st); err != nil {
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+		return
+	}
+}
+
+// extendsSigchain returns true if the statement seq and prev are next in the
+// sigchain. The statement itself isn't verified.
+func extendsSigchain(sc *keys.Sigchain, st *keys.Statement) (bool, error) {
+	if st.Seq != sc.LastSeq()+1 {
+		return false, nil
+	}
+	last := sc.Last()
+	if last == nil {
+		return len(st.Prev) == 0, nil
+	}
+	prev, err := keys.SigchainHash(last)
+	if err != nil {
+		return false, err
+	}
+	return bytes.Equal(st.Prev, prev[:]), nil
+}
+
+// RemoteSigchains is a client for sigchains served by SigchainHandler.
+type RemoteSigchains struct {
+	baseURL string
+	client  *http.Client
+}
+
+// NewRemoteSigchains creates a RemoteSigchains for a SigchainHandler at
+// baseURL. If client is nil, http.DefaultClient is used.
+func NewRemoteSigchains(baseURL string, client *http.Client) *RemoteSigchains {
+	if client == nil {
+		client = http.DefaultClient
+	}
+	return &RemoteSigchains{
+		baseURL: strings.TrimSuffix(baseURL, "/"),
+		client:  client,
+	}
+}
+
+// Statements returns statements after seq (from), or nil if the sigchain
+// wasn't found.
+// The statements are requested a page at a time.
+func (s *RemoteSigchains) Statements(kid keys.ID, from int) ([]*keys.Statement, error) {
+	sts := []*keys.Statement{}
+	for {
+		page, err := s.page(kid, from)
+		if err != nil {
+			return nil, err
+		}
+		if page == nil {
+			return nil, nil
+		}
+		if len(page) == 0 {
+			return sts, nil
+		}
+		last := page[len(page)-1].Seq
+		if last <= from {
+			return nil, errors.Errorf("invalid sigchain page")
+		}
+		sts = append(sts, page...)
+		from = last
+	}
+}
+
+func (s *RemoteSigchains) page(kid keys.ID, from int) ([]*keys.Statement, error) {
+	req, err := http.NewRequest("GET", s.baseURL+"/sigchain/"+kid.String()+"?from="+strconv.Itoa(from)+"&limit="+strconv.Itoa(sigchainPageLimit), nil)
+	if err != nil {
+		return nil, err
+	}
+	_, b, err := doRequestWithMax(s.client, req, nil, sigchainMaxSize)
+	if err != nil {
+		var herr Error
+		if errors.As(err, &herr) && herr.StatusCode == http.StatusNotFound {
+			return nil, nil
+		}
+		return nil, err
+	}
+	sts := []*keys.Statement{}
+	if err := json.Unmarshal(b, &sts); err != nil {
+		return nil, err
+	}
+	return sts, nil
+}
+
+// Sigchain returns the (verified) sigchain for kid.
+// If not found, returns an empty sigchain.
+// Statements must not have a context, see SigchainWithContext.
+func (s *RemoteSigchains) Sigchain(kid keys.ID) (*keys.Sigchain, error) {
+	return s.SigchainWithContext(kid, "")
+}
+
+// SigchainWithContext returns the (verified) sigchain for kid with a context
+// (see keys.NewSigchainWithContext).
+// Returns an error if a statement has a different context.
+func (s *RemoteSigchains) SigchainWithContext(kid keys.ID, context string) (*keys.Sigchain, error) {
+	sts, err := s.Statements(kid, 0)
+	if err != nil {
+		return nil, err
+	}
+	sc := keys.NewSigchainWithContext(kid, context)
+	if err := sc.AddAll(sts); err != nil {
+		return nil, err
+	}
+	return sc, nil
+}
+
+// Add a statement to the remote sigchain.
+func (s *RemoteSigchains) Add(st *keys.Statement) error {
+	b, err := st.MarshalJSON()
+	if err != nil {
+		return err
+	}
+	req, err := http.NewRequest("POST", s.baseURL+"/sigchain/"+st.KID.String(), bytes.NewReader(b))
+	if err != nil {
+		return err
+	}
+	if _, _, err := doRequest(s.client, req, nil); err != nil {
+		return err
+	}
+	return nil
+}
@@ -0,0 +1,198 @@
+package http_test
+
+import (
+	"bytes"
+	"encoding/json"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/keys-pub/keys"
+	"github.com/keys-pub/keys/dstore"
+	khttp "github.com/keys-pub/keys/http"
+	"github.com/keys-pub/keys/tsutil"
+	"github.com/stretchr/testify/require"
+)
+
+func TestSigchainHandler(t *testing.T) {
+	clock := tsutil.NewTestClock()
+	scs := keys.NewSigchains(dstore.NewMem())
+	server := httptest.NewServer(khttp.SigchainHandler(scs))
+	defer server.Close()
+	remote := khttp.NewRemoteSigchains(server.URL, server.Client())
+
+	alice := keys.NewEdX25519KeyFromSeed(keys.Bytes32(bytes.Repeat([]byte{0x01}, 32)))
+
+	// Not found
+	sts, err := remote.Statements(alice.ID(), 0)
+	require.NoError(t, err)
+	require.Nil(t, sts)
+
+	sc := keys.NewSigchain(alice.ID())
+	for i := 0; i < 3; i++ {
+		st, err := keys.NewSigchainStatement(sc, []byte("hi"), alice, "test", clock.Now())
+		require.NoError(t, err)
+		err = sc.Add(st)
+		require.NoError(t, err)
+		err = remote.Add(st)
+		require.NoError(t, err)
+	}
+
+	out, err := remote.Sigchain(alice.ID())
+	require.NoError(t, err)
+	require.Equal(t, sc.Spew().String(), out.Spew().String())
+
+	sts, err = remote.Statements(alice.ID(), 2)
+	require.NoError(t, err)
+	require.Equal(t, 1, len(sts))
+	require.Equal(t, 3, sts[0].Seq)
+
+	sts, err = remote.Statements(alice.ID(), 3)
+	require.NoError(t, err)
+	require.Equal(t, 0, len(sts))
+
+	// Statement that doesn't extend the chain
+	err = remote.Add(sc.Statements()[1])
+	require.EqualError(t, err, "http error 409")
+
+	fork := keys.NewSigchain(alice.ID())
+	st, err := keys.NewSigchainStatement(fork, []byte("fork"), alice, "test", clock.Now())
+	require.NoError(t, err)
+	err = remote.Add(st)
+	require.EqualError(t, err, "http error 409")
+
+	// Next seq with a different prev
+	st, err = keys.NewSigchainStatement(sc, []byte("hi"), alice, "test", clock.Now())
+	require.NoError(t, err)
+	st.Sig = nil
+	st.Prev = bytes.Repeat([]byte{0x01}, 32)
+	err = st.Sign(alice)
+	require.NoError(t, err)
+	err = remote.Add(st)
+	require.EqualError(t, err, "http error 409")
+
+	// Signed by a delegate that alice didn't authorize
+	mallory := keys.NewEdX25519KeyFromSeed(keys.Bytes32(bytes.Repeat([]byte{0x03}, 32)))
+	forged, err := keys.NewSigchainDelegatedStatement(sc, []byte("hi"), mallory, "test", clock.Now())
+	require.NoError(t, err)
+	err = remote.Add(forged)
+	require.EqualError(t, err, "http error 400")
+
+	// Invalid signature
+	st, err = keys.NewSigchainStatement(sc, []byte("hi"), alice, "test", clock.Now())
+	require.NoError(t, err)
+	b, err := st.Bytes()
+	require.NoError(t, err)
+	b = bytes.Replace(b, []byte(`"data":"aGk="`), []byte(`"data":"aG8="`), 1)
+	resp, err := server.Client().Post(server.URL+"/sigchain/"+alice.ID().String(), "application/json", bytes.NewReader(b))
+	require.NoError(t, err)
+	resp.Body.Close()
+	require.Equal(t, 400, resp.StatusCode)
+
+	stored, err := scs.Sigchain(alice.ID())
+	require.NoError(t, err)
+	require.Equal(t, 3, stored.Length())
+
+	// Delegated statement
+	dst, err := keys.NewDelegateStatement(sc, mallory.ID(), []string{"test"}, alice, clock.Now())
+	require.NoError(t, err)
+	err = sc.Add(dst)
+	require.NoError(t, err)
+	err = remote.Add(dst)
+	require.NoError(t, err)
+	st, err = keys.NewSigchainDelegatedStatement(sc, []byte("hi"), mallory, "test", clock.Now())
+	require.NoError(t, err)
+	err = sc.Add(st)
+	require.NoError(t, err)
+	err = remote.Add(st)
+	require.NoError(t, err)
+	out, err = remote.Sigchain(alice.ID())
+	require.NoError(t, err)
+	require.Equal(t, 5, out.Length())
+}
+
+func TestSigchainHandlerContext(t *testing.T) {
+	clock := tsutil.NewTestClock()
+	scs := keys.NewSigchains(dstore.NewMem())
+	server := httptest.NewServer(khttp.SigchainHandlerWithContext(scs, "app1"))
+	defer server.Close()
+	remote := khttp.NewRemoteSigchains(server.URL, server.Client())
+
+	alice := keys.NewEdX25519KeyFromSeed(keys.Bytes32(bytes.Repeat([]byte{0x01}, 32)))
+	bob := keys.NewEdX25519KeyFromSeed(keys.Bytes32(bytes.Repeat([]byte{0x02}, 32)))
+
+	sc := keys.NewSigchainWithContext(alice.ID(), "app1")
+	for i := 0; i < 2; i++ {
+		st, err := keys.NewSigchainStatement(sc, []byte("hi"), alice, "test", clock.Now())
+		require.NoError(t, err)
+		err = sc.Add(st)
+		require.NoError(t, err)
+		err = remote.Add(st)
+		require.NoError(t, err)
+	}
+
+	out, err := remote.SigchainWithContext(alice.ID(), "app1")
+	require.NoError(t, err)
+	require.Equal(t, 2, out.Length())
+	require.Equal(t, "app1", out.Context())
+
+	// Client expecting a different context
+	_, err = remote.Sigchain(alice.ID())
+	require.EqualError(t, err, "invalid statement context")
+	_, err = remote.SigchainWithContext(alice.ID(), "app2")
+	require.EqualError(t, err, "invalid statement context")
+
+	// Statement for a different context
+	scb := keys.NewSigchainWithContext(bob.ID(), "app2")
+	st, err := keys.NewSigchainStatement(scb, []byte("hi"), bob, "test", clock.Now())
+	require.NoError(t, err)
+	err = remote.Add(st)
+	require.EqualError(t, err, "http error 400")
+	sts, err := remote.Statements(bob.ID(), 0)
+	require.NoError(t, err)
+	require.Nil(t, sts)
+}
+
+func TestSigchainHandlerPages(t *testing.T) {
+	clock := tsutil.NewTestClock()
+	scs := keys.NewSigchains(dstore.NewMem())
+	server := httptest.NewServer(khttp.SigchainHandler(scs))
+	defer server.Close()
+	remote := khttp.NewRemoteSigchains(server.URL, server.Client())
+
+	alice := keys.NewEdX25519KeyFromSeed(keys.Bytes32(bytes.Repeat([]byte{0x01}, 32)))
+
+	// Larger than a single (max size) response
+	sc := keys.NewSigchain(alice.ID())
+	sizes := []int{700 * 1024, 700 * 1024, keys.DefaultSigchainLimits.MaxDataBytes, 16}
+	for _, size := range sizes {
+		st, err := keys.NewSigchainStatement(sc, bytes.Repeat([]byte{0x01}, size), alice, "test", clock.Now())
+		require.NoError(t, err)
+		err = sc.Add(st)
+		require.NoError(t, err)
+		err = remote.Add(st)
+		require.NoError(t, err)
+	}
+	for i := 0; i < 150; i++ {
+		st, err := keys.NewSigchainStatement(sc, []byte("hi"), alice, "test", clock.Now())
+		require.NoError(t, err)
+		err = sc.Add(st)
+		require.NoError(t, err)
+	}
+	err := scs.Save(sc)
+	require.NoError(t, err)
+
+	out, err := remote.Sigchain(alice.ID())
+	require.NoError(t, err)
+	require.Equal(t, sc.Length(), out.Length())
+	require.Equal(t, sc.Spew().String(), out.Spew().String())
+
+	// Limit
+	resp, err := server.Client().Get(server.URL + "/sigchain/" + alice.ID().String() + "?from=10&limit=2")
+	require.NoError(t, err)
+	defer resp.Body.Close()
+	var sts []*keys.Statement
+	err = json.NewDecoder(resp.Body).Decode(&sts)
+	require.NoError(t, err)
+	require.Equal(t, 2, len(sts))
+	require.Equal(t, 11, sts[0].Seq)
+}
@@ -2,6 +2,7 @@ package keys
 
 import (
 	"crypto/sha256"
+	"crypto/subtle"
 	"fmt"
 	"strings"
 
@@ -58,6 +59,30 @@ func IsValidID(s string) bool {
 	return err == nil
 }
 
+// EqualIDs returns true if IDs are equal (in constant time).
+func EqualIDs(a ID, b ID) bool {
+	return subtle.ConstantTimeCompare([]byte(a), []byte(b)) == 1
+}
+
+// IsValid returns true if ID is a valid (bech32) ID.
+func (i ID) IsValid() bool {
+	return IsValidID(string(i))
+}
+
+// PublicKey returns the public key for the ID.
+// Returns *EdX25519PublicKey or *X25519PublicKey, or an error if the ID isn't
+// for one of those key types.
+func (i ID) PublicKey() (Key, error) {
+	switch i.Type() {
+	case EdX25519:
+		return NewEdX25519PublicKeyFromID(i)
+	case X25519:
+		return NewX25519PublicKeyFromID(i)
+	default:
+		return nil, errors.Errorf("unsupported id %s", i)
+	}
+}
+
 // RandID returns a random (bech32) ID.
 func RandID(hrp string) ID {
 	b := Rand32()
@@ -133,6 +158,8 @@ func (i ID) Type() KeyType {
 		return EdX25519
 	case x25519KeyHRP:
 		return X25519
+	case rsaKeyHRP:
+		return RSA
 	default:
 		return ""
 	}
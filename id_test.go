@@ -20,6 +20,10 @@ func TestID(t *testing.T) {
 	require.Equal(t, bid, bk.ID())
 	require.Equal(t, bk.Public(), bid.Public())
 	require.Equal(t, keys.KeyType("x25519"), bid.Type())
+
+	rk := keys.GenerateRSAKey()
+	require.Equal(t, keys.RSA, rk.ID().Type())
+	require.Equal(t, keys.KeyType(""), keys.RandID("test").Type())
 }
 
 func TestIDErrors(t *testing.T) {
@@ -32,6 +36,27 @@ func TestIDErrors(t *testing.T) {
 	require.EqualError(t, err, "failed to parse id: separator '1' at invalid position: pos=-1, len=3")
 }
 
+func TestIDPublicKey(t *testing.T) {
+	sk := keys.NewEdX25519KeyFromSeed(testSeed(0x01))
+	require.True(t, sk.ID().IsValid())
+	pk, err := sk.ID().PublicKey()
+	require.NoError(t, err)
+	require.Equal(t, sk.PublicKey(), pk)
+
+	bk := keys.NewX25519KeyFromSeed(testSeed(0x01))
+	pk, err = bk.ID().PublicKey()
+	require.NoError(t, err)
+	require.Equal(t, bk.PublicKey(), pk)
+
+	require.False(t, keys.ID("").IsValid())
+	require.False(t, keys.ID("kex132yw8ht5p8cetl2jmvknewjawt9xwzdlrk2pyxlnwjyqrdq0dawqqph078").IsValid())
+
+	rid := keys.RandID("test")
+	require.True(t, rid.IsValid())
+	_, err = rid.PublicKey()
+	require.EqualError(t, err, "unsupported id "+rid.String())
+}
+
 func TestIDUUID(t *testing.T) {
 	id := keys.ID("kex132yw8ht5p8cetl2jmvknewjawt9xwzdlrk2pyxlnwjyqrdq0dawqqph077")
 	require.Equal(t, "34750f98bd59fcfc946da45aaabe933b", hex.EncodeToString(id.UUID()[:]))
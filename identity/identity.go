@@ -0,0 +1,159 @@
+// Package identity bundles a key, its sigchain and users.
+package identity
+
+import (
+	"encoding/json"
+
+	"github.com/keys-pub/keys"
+	"github.com/keys-pub/keys/api"
+	"github.com/keys-pub/keys/keyring"
+	"github.com/keys-pub/keys/tsutil"
+	"github.com/keys-pub/keys/user"
+	"github.com/pkg/errors"
+)
+
+// Identity is a EdX25519Key with its Sigchain.
+// It's a convenience over the keys, keys.Sigchain and user packages, which can
+// still be used directly.
+type Identity struct {
+	key   *keys.EdX25519Key
+	sc    *keys.Sigchain
+	clock tsutil.Clock
+}
+
+// New creates an Identity with an empty Sigchain.
+func New(key *keys.EdX25519Key) *Identity {
+	return NewWithContext(key, "")
+}
+
+// NewWithContext creates an Identity with an empty Sigchain for a context (see
+// keys.NewSigchainWithContext).
+func NewWithContext(key *keys.EdX25519Key, context string) *Identity {
+	return &Identity{
+		key:   key,
+		sc:    keys.NewSigchainWithContext(key.ID(), context),
+		clock: tsutil.NewClock(),
+	}
+}
+
+// SetClock to use a custom time.Now.
+func (i *Identity) SetClock(clock tsutil.Clock) {
+	i.clock = clock
+}
+
+// ID is the key ID.
+func (i *Identity) ID() keys.ID {
+	return i.key.ID()
+}
+
+// Key for the identity.
+func (i *Identity) Key() *keys.EdX25519Key {
+	return i.key
+}
+
+// Sigchain for the identity.
+func (i *Identity) Sigchain() *keys.Sigchain {
+	return i.sc
+}
+
+// Sign data into a statement of type, and add it to the Sigchain.
+func (i *Identity) Sign(b []byte, typ string) (*keys.Statement, error) {
+	st, err := keys.NewSigchainStatement(i.sc, b, i.key, typ, i.clock.Now())
+	if err != nil {
+		return nil, err
+	}
+	if err := i.sc.Add(st); err != nil {
+		return nil, err
+	}
+	return st, nil
+}
+
+// LinkUser adds a user statement to the Sigchain.
+// The user (statement) should be published at the URL, see User.Sign.
+func (i *Identity) LinkUser(service string, name string, urs string) (*user.User, error) {
+	usr, err := user.New(i.key.ID(), service, name, urs, i.sc.LastSeq()+1)
+	if err != nil {
+		return nil, err
+	}
+	st, err := user.NewSigchainStatement(i.sc, usr, i.key, i.clock.Now())
+	if err != nil {
+		return nil, err
+	}
+	if err := i.sc.Add(st); err != nil {
+		return nil, err
+	}
+	return usr, nil
+}
+
+// Users linked in the Sigchain.
+func (i *Identity) Users() ([]*user.User, error) {
+	usr, err := user.FindInSigchain(i.sc)
+	if err != nil {
+		return nil, err
+	}
+	if usr == nil {
+		return []*user.User{}, nil
+	}
+	return []*user.User{usr}, nil
+}
+
+// Save the identity to a Keyring.
+// The key is saved with keyring.SaveKey and the sigchain statements are saved
+// as "{kid}.sigchain".
+func (i *Identity) Save(kr keyring.Keyring) error {
+	if err := keyring.SaveKey(kr, api.NewKey(i.key)); err != nil {
+		return err
+	}
+	scb, err := json.Marshal(i.sc.Statements())
+	if err != nil {
+		return err
+	}
+	if err := kr.Set(sigchainID(i.key.ID()), scb); err != nil {
+		return err
+	}
+	return nil
+}
+
+// Load an identity from a Keyring.
+// Returns keys.ErrNotFound if not found.
+// The sigchain must not have a context, see LoadWithContext.
+func Load(kr keyring.Keyring, kid keys.ID) (*Identity, error) {
+	return LoadWithContext(kr, kid, "")
+}
+
+// LoadWithContext loads an identity with a sigchain for a context (see
+// NewWithContext) from a Keyring.
+// Returns an error if a statement has a different context.
+func LoadWithContext(kr keyring.Keyring, kid keys.ID, context string) (*Identity, error) {
+	key, err := keyring.LoadKey(kr, kid)
+	if err != nil {
+		return nil, err
+	}
+	if key == nil {
+		return nil, keys.NewErrNotFound(kid.String())
+	}
+	sk := key.AsEdX25519()
+	if sk == nil {
+		return nil, errors.Errorf("invalid identity key type %s", key.Type)
+	}
+	identity := NewWithContext(sk, context)
+
+	scb, err := kr.Get(sigchainID(kid))
+	if err != nil {
+		return nil, err
+	}
+	if scb != nil {
+		var sts []*keys.Statement
+		if err := json.Unmarshal(scb, &sts); err != nil {
+			return nil, err
+		}
+		if err := identity.sc.AddAll(sts); err != nil {
+			return nil, err
+		}
+	}
+	return identity, nil
+}
+
+func sigchainID(kid keys.ID) string {
+	return kid.String() + ".sigchain"
+}
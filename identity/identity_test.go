@@ -0,0 +1,99 @@
+package identity_test
+
+import (
+	"bytes"
+	"testing"
+
+	"github.com/keys-pub/keys"
+	"github.com/keys-pub/keys/identity"
+	"github.com/keys-pub/keys/keyring"
+	"github.com/keys-pub/keys/tsutil"
+	"github.com/stretchr/testify/require"
+)
+
+func testSeed(b byte) *[32]byte {
+	return keys.Bytes32(bytes.Repeat([]byte{b}, 32))
+}
+
+func TestIdentity(t *testing.T) {
+	clock := tsutil.NewTestClock()
+	sk := keys.NewEdX25519KeyFromSeed(testSeed(0x01))
+
+	alice := identity.New(sk)
+	alice.SetClock(clock)
+
+	users, err := alice.Users()
+	require.NoError(t, err)
+	require.Equal(t, 0, len(users))
+
+	st, err := alice.Sign([]byte("hi"), "test")
+	require.NoError(t, err)
+	require.Equal(t, 1, st.Seq)
+
+	usr, err := alice.LinkUser("github", "alice", "https://gist.github.com/alice/1")
+	require.NoError(t, err)
+	require.Equal(t, 2, usr.Seq)
+	require.Equal(t, 2, alice.Sigchain().Length())
+
+	_, err = alice.LinkUser("github", "alice", "https://gist.github.com/alice/2")
+	require.EqualError(t, err, "user set in sigchain already")
+
+	users, err = alice.Users()
+	require.NoError(t, err)
+	require.Equal(t, 1, len(users))
+	require.Equal(t, "alice@github", users[0].ID())
+
+	// Save and load
+	kr := keyring.NewMem()
+	err = alice.Save(kr)
+	require.NoError(t, err)
+
+	kids, err := keyring.KeyIDs(kr)
+	require.NoError(t, err)
+	require.Equal(t, []keys.ID{sk.ID()}, kids)
+
+	out, err := identity.Load(kr, sk.ID())
+	require.NoError(t, err)
+	require.True(t, sk.Equal(out.Key()))
+	require.Equal(t, alice.Sigchain().Spew().String(), out.Sigchain().Spew().String())
+	users, err = out.Users()
+	require.NoError(t, err)
+	require.Equal(t, 1, len(users))
+	require.Equal(t, usr, users[0])
+
+	bob := keys.NewEdX25519KeyFromSeed(testSeed(0x02))
+	_, err = identity.Load(kr, bob.ID())
+	require.Equal(t, keys.NewErrNotFound(bob.ID().String()), err)
+}
+
+func TestIdentityContext(t *testing.T) {
+	clock := tsutil.NewTestClock()
+	sk := keys.NewEdX25519KeyFromSeed(testSeed(0x01))
+
+	alice := identity.NewWithContext(sk, "app1")
+	alice.SetClock(clock)
+	st, err := alice.Sign([]byte("hi"), "test")
+	require.NoError(t, err)
+	require.Equal(t, "app1", st.Context)
+
+	kr := keyring.NewMem()
+	err = alice.Save(kr)
+	require.NoError(t, err)
+
+	out, err := identity.LoadWithContext(kr, sk.ID(), "app1")
+	require.NoError(t, err)
+	require.Equal(t, "app1", out.Sigchain().Context())
+	require.Equal(t, 1, out.Sigchain().Length())
+
+	// Signing after load keeps the context
+	out.SetClock(clock)
+	st, err = out.Sign([]byte("hi2"), "test")
+	require.NoError(t, err)
+	require.Equal(t, "app1", st.Context)
+
+	// Loading for a different context fails
+	_, err = identity.Load(kr, sk.ID())
+	require.EqualError(t, err, "invalid statement context")
+	_, err = identity.LoadWithContext(kr, sk.ID(), "app2")
+	require.EqualError(t, err, "invalid statement context")
+}
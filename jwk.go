@@ -0,0 +1,208 @@
+package keys
+
+import (
+	"bytes"
+	"crypto/rsa"
+	"encoding/base64"
+	"encoding/json"
+	"math/big"
+
+	"github.com/pkg/errors"
+)
+
+// jwk is a JSON Web Key for an OKP (Octet Key Pair, RFC 8037) or RSA
+// (RFC 7518) key.
+type jwk struct {
+	Kty string `json:"kty"`
+	Crv string `json:"crv,omitempty"`
+	X   string `json:"x,omitempty"`
+	N   string `json:"n,omitempty"`
+	E   string `json:"e,omitempty"`
+	D   string `json:"d,omitempty"`
+	P   string `json:"p,omitempty"`
+	Q   string `json:"q,omitempty"`
+	DP  string `json:"dp,omitempty"`
+	DQ  string `json:"dq,omitempty"`
+	QI  string `json:"qi,omitempty"`
+}
+
+func marshalJWK(crv string, public []byte, private []byte) ([]byte, error) {
+	k := jwk{
+		Kty: "OKP",
+		Crv: crv,
+		X:   base64.RawURLEncoding.EncodeToString(public),
+	}
+	if private != nil {
+		k.D = base64.RawURLEncoding.EncodeToString(private)
+	}
+	return json.Marshal(k)
+}
+
+// MarshalJWK encodes the key as a JSON Web Key (OKP, Ed25519).
+// The private key "d" is the 32 byte seed.
+func (k *EdX25519Key) MarshalJWK() ([]byte, error) {
+	return marshalJWK("Ed25519", k.Public(), k.Seed()[:])
+}
+
+// MarshalJWK encodes the public key as a JSON Web Key (OKP, Ed25519).
+func (k *EdX25519PublicKey) MarshalJWK() ([]byte, error) {
+	return marshalJWK("Ed25519", k.Bytes(), nil)
+}
+
+// MarshalJWK encodes the key as a JSON Web Key (OKP, X25519).
+func (k *X25519Key) MarshalJWK() ([]byte, error) {
+	return marshalJWK("X25519", k.Public(), k.Private())
+}
+
+// MarshalJWK encodes the public key as a JSON Web Key (OKP, X25519).
+func (k *X25519PublicKey) MarshalJWK() ([]byte, error) {
+	return marshalJWK("X25519", k.Bytes(), nil)
+}
+
+func jwkInt(i *big.Int) string {
+	return base64.RawURLEncoding.EncodeToString(i.Bytes())
+}
+
+func marshalRSAJWK(pk *rsa.PublicKey, sk *rsa.PrivateKey) ([]byte, error) {
+	k := jwk{
+		Kty: "RSA",
+		N:   jwkInt(pk.N),
+		E:   jwkInt(big.NewInt(int64(pk.E))),
+	}
+	if sk != nil {
+		if len(sk.Primes) != 2 {
+			return nil, errors.Errorf("unsupported rsa key (multi-prime)")
+		}
+		sk.Precompute()
+		k.D = jwkInt(sk.D)
+		k.P = jwkInt(sk.Primes[0])
+		k.Q = jwkInt(sk.Primes[1])
+		k.DP = jwkInt(sk.Precomputed.Dp)
+		k.DQ = jwkInt(sk.Precomputed.Dq)
+		k.QI = jwkInt(sk.Precomputed.Qinv)
+	}
+	return json.Marshal(k)
+}
+
+// MarshalJWK encodes the key as a JSON Web Key (RSA), with the private
+// exponent "d" and primes (and CRT values).
+func (k *RSAKey) MarshalJWK() ([]byte, error) {
+	return marshalRSAJWK(&k.privateKey.PublicKey, k.privateKey)
+}
+
+// MarshalJWK encodes the public key as a JSON Web Key (RSA).
+func (k *RSAPublicKey) MarshalJWK() ([]byte, error) {
+	return marshalRSAJWK(k.pk, nil)
+}
+
+// ParseJWK parses a JSON Web Key, OKP with crv Ed25519 or X25519, or RSA.
+// If the private key "d" is present, returns *EdX25519Key, *X25519Key or
+// *RSAKey, otherwise returns *EdX25519PublicKey, *X25519PublicKey or
+// *RSAPublicKey.
+func ParseJWK(b []byte) (Key, error) {
+	var k jwk
+	if err := json.Unmarshal(b, &k); err != nil {
+		return nil, errors.Wrapf(err, "failed to parse jwk")
+	}
+	switch k.Kty {
+	case "OKP":
+		return parseOKPJWK(k)
+	case "RSA":
+		return parseRSAJWK(k)
+	default:
+		return nil, errors.Errorf("unsupported jwk kty %q", k.Kty)
+	}
+}
+
+func parseOKPJWK(k jwk) (Key, error) {
+	if k.Crv != "Ed25519" && k.Crv != "X25519" {
+		return nil, errors.Errorf("unsupported jwk crv %q", k.Crv)
+	}
+	x, err := base64.RawURLEncoding.DecodeString(k.X)
+	if err != nil {
+		return nil, errors.Wrapf(err, "invalid jwk x")
+	}
+	if len(x) != 32 {
+		return nil, errors.Errorf("invalid jwk x length")
+	}
+
+	if k.D == "" {
+		switch k.Crv {
+		case "Ed25519":
+			return NewEdX25519PublicKey(Bytes32(x)), nil
+		default:
+			return NewX25519PublicKey(Bytes32(x)), nil
+		}
+	}
+
+	d, err := base64.RawURLEncoding.DecodeString(k.D)
+	if err != nil {
+		return nil, errors.Wrapf(err, "invalid jwk d")
+	}
+	if len(d) != 32 {
+		return nil, errors.Errorf("invalid jwk d length")
+	}
+	var key Key
+	switch k.Crv {
+	case "Ed25519":
+		key = NewEdX25519KeyFromSeed(Bytes32(d))
+	default:
+		key = NewX25519KeyFromPrivateKey(Bytes32(d))
+	}
+	if !bytes.Equal(key.Public(), x) {
+		return nil, errors.Errorf("jwk public key mismatch")
+	}
+	return key, nil
+}
+
+func parseJWKInt(s string, name string) (*big.Int, error) {
+	if s == "" {
+		return nil, errors.Errorf("missing jwk %s", name)
+	}
+	b, err := base64.RawURLEncoding.DecodeString(s)
+	if err != nil {
+		return nil, errors.Wrapf(err, "invalid jwk %s", name)
+	}
+	return new(big.Int).SetBytes(b), nil
+}
+
+func parseRSAJWK(k jwk) (Key, error) {
+	n, err := parseJWKInt(k.N, "n")
+	if err != nil {
+		return nil, err
+	}
+	e, err := parseJWKInt(k.E, "e")
+	if err != nil {
+		return nil, err
+	}
+	if !e.IsInt64() || e.Int64() < 2 || e.Int64() > 1<<31-1 {
+		return nil, errors.Errorf("invalid jwk e")
+	}
+	pk := rsa.PublicKey{N: n, E: int(e.Int64())}
+	if k.D == "" {
+		return NewRSAPublicKey(&pk), nil
+	}
+
+	d, err := parseJWKInt(k.D, "d")
+	if err != nil {
+		return nil, err
+	}
+	p, err := parseJWKInt(k.P, "p")
+	if err != nil {
+		return nil, err
+	}
+	q, err := parseJWKInt(k.Q, "q")
+	if err != nil {
+		return nil, err
+	}
+	sk := &rsa.PrivateKey{
+		PublicKey: pk,
+		D:         d,
+		Primes:    []*big.Int{p, q},
+	}
+	if err := sk.Validate(); err != nil {
+		return nil, errors.Wrapf(err, "invalid jwk rsa key")
+	}
+	sk.Precompute()
+	return NewRSAKey(sk), nil
+}
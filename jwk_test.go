@@ -0,0 +1,83 @@
+package keys_test
+
+import (
+	"testing"
+
+	"github.com/keys-pub/keys"
+	"github.com/keys-pub/keys/encoding"
+	"github.com/stretchr/testify/require"
+)
+
+func TestJWK(t *testing.T) {
+	// From RFC 8037, Appendix A.
+	seed := encoding.MustDecode("9d61b19deffd5a60ba844af492ec2cc44449c5697b326919703bac031cae7f60", encoding.Hex)
+	sk := keys.NewEdX25519KeyFromSeed(keys.Bytes32(seed))
+
+	b, err := sk.MarshalJWK()
+	require.NoError(t, err)
+	expected := `{"kty":"OKP","crv":"Ed25519","x":"11qYAYKxCrfVS_7TyWQHOg7hcvPapiMlrwIaaPcHURo","d":"nWGxne_9WmC6hEr0kuwsxERJxWl7MmkZcDusAxyuf2A"}`
+	require.Equal(t, expected, string(b))
+
+	key, err := keys.ParseJWK(b)
+	require.NoError(t, err)
+	require.Equal(t, sk, key)
+
+	b, err = sk.PublicKey().MarshalJWK()
+	require.NoError(t, err)
+	require.Equal(t, `{"kty":"OKP","crv":"Ed25519","x":"11qYAYKxCrfVS_7TyWQHOg7hcvPapiMlrwIaaPcHURo"}`, string(b))
+	key, err = keys.ParseJWK(b)
+	require.NoError(t, err)
+	require.Equal(t, sk.PublicKey(), key)
+
+	// X25519
+	bk := keys.NewX25519KeyFromSeed(testSeed(0x01))
+	b, err = bk.MarshalJWK()
+	require.NoError(t, err)
+	key, err = keys.ParseJWK(b)
+	require.NoError(t, err)
+	require.Equal(t, bk, key)
+
+	b, err = bk.PublicKey().MarshalJWK()
+	require.NoError(t, err)
+	key, err = keys.ParseJWK(b)
+	require.NoError(t, err)
+	require.Equal(t, bk.PublicKey(), key)
+
+	// Unsupported
+	_, err = keys.ParseJWK([]byte(`{"kty":"OKP","crv":"Ed448","x":"11qYAYKxCrfVS_7TyWQHOg7hcvPapiMlrwIaaPcHURo"}`))
+	require.EqualError(t, err, `unsupported jwk crv "Ed448"`)
+	_, err = keys.ParseJWK([]byte(`{"kty":"EC","crv":"P-256"}`))
+	require.EqualError(t, err, `unsupported jwk kty "EC"`)
+
+	// Mismatched public key
+	_, err = keys.ParseJWK([]byte(`{"kty":"OKP","crv":"Ed25519","x":"11qYAYKxCrfVS_7TyWQHOg7hcvPapiMlrwIaaPcHURo","d":"AQEBAQEBAQEBAQEBAQEBAQEBAQEBAQEBAQEBAQEBAQE"}`))
+	require.EqualError(t, err, "jwk public key mismatch")
+}
+
+func TestRSAJWK(t *testing.T) {
+	rk := keys.GenerateRSAKey()
+
+	b, err := rk.MarshalJWK()
+	require.NoError(t, err)
+	require.Contains(t, string(b), `{"kty":"RSA","n":"`)
+	require.Contains(t, string(b), `"e":"AQAB","d":"`)
+	key, err := keys.ParseJWK(b)
+	require.NoError(t, err)
+	require.Equal(t, rk.ID(), key.ID())
+	require.Equal(t, rk.Private(), key.Private())
+
+	b, err = rk.PublicKey().MarshalJWK()
+	require.NoError(t, err)
+	require.NotContains(t, string(b), `"d"`)
+	key, err = keys.ParseJWK(b)
+	require.NoError(t, err)
+	require.Equal(t, rk.PublicKey().Bytes(), key.Public())
+	require.Equal(t, keys.RSA, key.Type())
+
+	// Private key without primes
+	_, err = keys.ParseJWK([]byte(`{"kty":"RSA","n":"AQAB","e":"AQAB","d":"AQAB"}`))
+	require.EqualError(t, err, "missing jwk p")
+	// Missing modulus
+	_, err = keys.ParseJWK([]byte(`{"kty":"RSA","e":"AQAB"}`))
+	require.EqualError(t, err, "missing jwk n")
+}
@@ -9,6 +9,7 @@ import (
 	"os"
 	"time"
 
+	"github.com/keys-pub/keys"
 	"github.com/pkg/errors"
 )
 
@@ -22,7 +23,7 @@ func Backup(path string, kr Keyring, now time.Time) error {
 		return err
 	}
 
-	if err := backup(file, kr, now); err != nil {
+	if err := backup(file, kr, "", now); err != nil {
 		_ = file.Close()
 		return err
 	}
@@ -38,11 +39,11 @@ func Backup(path string, kr Keyring, now time.Time) error {
 	return nil
 }
 
-func backup(file *os.File, kr Keyring, now time.Time) error {
-	gz := gzip.NewWriter(file)
+func backup(w io.Writer, kr Keyring, prefix string, now time.Time) error {
+	gz := gzip.NewWriter(w)
 	tw := tar.NewWriter(gz)
 
-	items, err := kr.Items("")
+	items, err := kr.Items(prefix)
 	if err != nil {
 		_ = tw.Close()
 		_ = gz.Close()
@@ -85,7 +86,7 @@ func Restore(path string, kr Keyring) error {
 		return errors.Wrapf(err, "failed to open backup")
 	}
 
-	if err := restore(file, kr); err != nil {
+	if err := restore(file, kr, ImportOverwrite); err != nil {
 		_ = file.Close()
 		return err
 	}
@@ -94,8 +95,8 @@ func Restore(path string, kr Keyring) error {
 
 }
 
-func restore(file *os.File, kr Keyring) error {
-	gz, err := gzip.NewReader(file)
+func restore(r io.Reader, kr Keyring, conflict ImportConflict) error {
+	gz, err := gzip.NewReader(r)
 	if err != nil {
 		return errors.Wrapf(err, "failed to open gzip")
 	}
@@ -120,6 +121,18 @@ func restore(file *os.File, kr Keyring) error {
 			}
 
 			path := header.Name
+			if conflict != ImportOverwrite {
+				exists, err := kr.Exists(path)
+				if err != nil {
+					return err
+				}
+				if exists {
+					if conflict == ImportSkip {
+						continue
+					}
+					return errors.Errorf("item %s already exists", path)
+				}
+			}
 			if err := kr.Set(path, b); err != nil {
 				return err
 			}
@@ -131,3 +144,65 @@ func restore(file *os.File, kr Keyring) error {
 
 	return nil
 }
+
+// Export items with ID prefix into a password encrypted bundle.
+// Use Import to add them to a keyring.
+func Export(kr Keyring, prefix string, password string, now time.Time) ([]byte, error) {
+	if password == "" {
+		return nil, errors.Errorf("empty password")
+	}
+	var buf bytes.Buffer
+	if err := backup(&buf, kr, prefix, now); err != nil {
+		return nil, err
+	}
+	return keys.EncryptWithPassword(buf.Bytes(), password), nil
+}
+
+// ImportConflict is how Import handles an item that already exists.
+type ImportConflict string
+
+const (
+	// ImportOverwrite replaces existing items (default).
+	ImportOverwrite ImportConflict = ""
+	// ImportSkip keeps existing items.
+	ImportSkip ImportConflict = "skip"
+	// ImportFail returns an error if an item exists.
+	// Items imported before the conflicting item are kept.
+	ImportFail ImportConflict = "fail"
+)
+
+// ImportOptions for Import.
+type ImportOptions struct {
+	// Conflict is how to handle existing items.
+	Conflict ImportConflict
+}
+
+// ImportOption for Import.
+type ImportOption func(*ImportOptions)
+
+func newImportOptions(opts ...ImportOption) ImportOptions {
+	var options ImportOptions
+	for _, o := range opts {
+		o(&options)
+	}
+	return options
+}
+
+// OnConflict option, for how to handle existing items.
+func OnConflict(conflict ImportConflict) ImportOption {
+	return func(o *ImportOptions) {
+		o.Conflict = conflict
+	}
+}
+
+// Import items from an Export bundle into a keyring.
+// By default, existing items with the same ID are overwritten (see
+// OnConflict).
+func Import(b []byte, password string, kr Keyring, opt ...ImportOption) error {
+	opts := newImportOptions(opt...)
+	decrypted, err := keys.DecryptWithPassword(b, password)
+	if err != nil {
+		return err
+	}
+	return restore(bytes.NewReader(decrypted), kr, opts.Conflict)
+}
@@ -50,3 +50,70 @@ func testEqualKeyrings(t *testing.T, kr1 keyring.Keyring, kr2 keyring.Keyring) {
 		require.Equal(t, b1, b2)
 	}
 }
+
+func TestExportImport(t *testing.T) {
+	clock := tsutil.NewTestClock()
+
+	kr := keyring.NewMem()
+	for i := 0; i < 3; i++ {
+		err := kr.Set(dstore.Path("sign", i), []byte(fmt.Sprintf("sign%d", i)))
+		require.NoError(t, err)
+		err = kr.Set(dstore.Path("other", i), []byte(fmt.Sprintf("other%d", i)))
+		require.NoError(t, err)
+	}
+
+	b, err := keyring.Export(kr, "/sign", "testpassword", clock.Now())
+	require.NoError(t, err)
+
+	_, err = keyring.Export(kr, "/sign", "", clock.Now())
+	require.EqualError(t, err, "empty password")
+
+	kr2 := keyring.NewMem()
+	err = keyring.Import(b, "invalidpassword", kr2)
+	require.EqualError(t, err, "failed to decrypt with a password: secretbox open failed")
+
+	err = kr2.Set("existing", []byte("existing"))
+	require.NoError(t, err)
+	err = keyring.Import(b, "testpassword", kr2)
+	require.NoError(t, err)
+
+	ids, err := keyring.IDs(kr2, "")
+	require.NoError(t, err)
+	require.Equal(t, []string{"/sign/0", "/sign/1", "/sign/2", "existing"}, ids)
+	out, err := kr2.Get("/sign/1")
+	require.NoError(t, err)
+	require.Equal(t, []byte("sign1"), out)
+}
+
+func TestImportConflict(t *testing.T) {
+	clock := tsutil.NewTestClock()
+
+	kr := keyring.NewMem()
+	err := kr.Set("a", []byte("a"))
+	require.NoError(t, err)
+	err = kr.Set("b", []byte("b"))
+	require.NoError(t, err)
+	b, err := keyring.Export(kr, "", "testpassword", clock.Now())
+	require.NoError(t, err)
+
+	kr2 := keyring.NewMem()
+	err = kr2.Set("b", []byte("b2"))
+	require.NoError(t, err)
+	err = keyring.Import(b, "testpassword", kr2, keyring.OnConflict(keyring.ImportFail))
+	require.EqualError(t, err, "item b already exists")
+
+	err = keyring.Import(b, "testpassword", kr2, keyring.OnConflict(keyring.ImportSkip))
+	require.NoError(t, err)
+	out, err := kr2.Get("a")
+	require.NoError(t, err)
+	require.Equal(t, []byte("a"), out)
+	out, err = kr2.Get("b")
+	require.NoError(t, err)
+	require.Equal(t, []byte("b2"), out)
+
+	err = keyring.Import(b, "testpassword", kr2)
+	require.NoError(t, err)
+	out, err = kr2.Get("b")
+	require.NoError(t, err)
+	require.Equal(t, []byte("b"), out)
+}
@@ -0,0 +1,92 @@
+package keyring
+
+import (
+	"github.com/pkg/errors"
+)
+
+// chunkStore stores numbered chunks for an item ID.
+// Chunk 0 is the item itself, so small items are stored as before.
+type chunkStore interface {
+	// getChunk returns nil if the chunk doesn't exist.
+	getChunk(id string, n int) ([]byte, error)
+	setChunk(id string, n int, b []byte) error
+	deleteChunk(id string, n int) (bool, error)
+}
+
+// getChunks reads chunks for id and joins them.
+// Chunks are read until one is missing or shorter than size.
+func getChunks(st chunkStore, id string, size int) ([]byte, error) {
+	b, err := st.getChunk(id, 0)
+	if err != nil {
+		return nil, err
+	}
+	if b == nil {
+		return nil, nil
+	}
+	out := b
+	for n := 1; len(b) == size; n++ {
+		b, err = st.getChunk(id, n)
+		if err != nil {
+			return nil, err
+		}
+		if b == nil {
+			break
+		}
+		out = append(out, b...)
+	}
+	return out, nil
+}
+
+// setChunks splits data into chunks of at most size bytes and stores them,
+// removing any chunks left over from a previous (larger) value.
+func setChunks(st chunkStore, id string, data []byte, size int) error {
+	if size <= 0 {
+		return errors.Errorf("invalid chunk size")
+	}
+	chunks := splitChunks(data, size)
+	for n, chunk := range chunks {
+		if err := st.setChunk(id, n, chunk); err != nil {
+			return err
+		}
+	}
+	return deleteChunksFrom(st, id, len(chunks))
+}
+
+// deleteChunks removes all chunks for id.
+func deleteChunks(st chunkStore, id string) (bool, error) {
+	ok, err := st.deleteChunk(id, 0)
+	if err != nil {
+		return false, err
+	}
+	if !ok {
+		return false, nil
+	}
+	if err := deleteChunksFrom(st, id, 1); err != nil {
+		return false, err
+	}
+	return true, nil
+}
+
+func deleteChunksFrom(st chunkStore, id string, n int) error {
+	for ; ; n++ {
+		ok, err := st.deleteChunk(id, n)
+		if err != nil {
+			return err
+		}
+		if !ok {
+			return nil
+		}
+	}
+}
+
+func splitChunks(b []byte, size int) [][]byte {
+	if len(b) <= size {
+		return [][]byte{b}
+	}
+	chunks := make([][]byte, 0, (len(b)+size-1)/size)
+	for len(b) > size {
+		chunks = append(chunks, b[:size])
+		b = b[size:]
+	}
+	return append(chunks, b)
+}
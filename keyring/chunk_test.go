@@ -0,0 +1,73 @@
+package keyring
+
+import (
+	"bytes"
+	"fmt"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+type testChunkStore struct {
+	m map[string][]byte
+}
+
+func (s *testChunkStore) key(id string, n int) string {
+	return fmt.Sprintf("%s/%d", id, n)
+}
+
+func (s *testChunkStore) getChunk(id string, n int) ([]byte, error) {
+	return s.m[s.key(id, n)], nil
+}
+
+func (s *testChunkStore) setChunk(id string, n int, b []byte) error {
+	s.m[s.key(id, n)] = append([]byte{}, b...)
+	return nil
+}
+
+func (s *testChunkStore) deleteChunk(id string, n int) (bool, error) {
+	if _, ok := s.m[s.key(id, n)]; !ok {
+		return false, nil
+	}
+	delete(s.m, s.key(id, n))
+	return true, nil
+}
+
+func TestChunks(t *testing.T) {
+	st := &testChunkStore{m: map[string][]byte{}}
+	size := 10
+
+	for _, n := range []int{1, 9, 10, 11, 20, 25} {
+		data := bytes.Repeat([]byte{byte(n)}, n)
+		err := setChunks(st, "key1", data, size)
+		require.NoError(t, err)
+		require.Equal(t, (n+size-1)/size, len(st.m))
+
+		out, err := getChunks(st, "key1", size)
+		require.NoError(t, err)
+		require.Equal(t, data, out)
+	}
+
+	// Shrinking removes leftover chunks
+	err := setChunks(st, "key1", []byte("small"), size)
+	require.NoError(t, err)
+	require.Equal(t, 1, len(st.m))
+	out, err := getChunks(st, "key1", size)
+	require.NoError(t, err)
+	require.Equal(t, []byte("small"), out)
+
+	err = setChunks(st, "key1", bytes.Repeat([]byte{0x01}, 35), size)
+	require.NoError(t, err)
+	require.Equal(t, 4, len(st.m))
+	ok, err := deleteChunks(st, "key1")
+	require.NoError(t, err)
+	require.True(t, ok)
+	require.Equal(t, 0, len(st.m))
+
+	out, err = getChunks(st, "key1", size)
+	require.NoError(t, err)
+	require.Nil(t, out)
+	ok, err = deleteChunks(st, "key1")
+	require.NoError(t, err)
+	require.False(t, ok)
+}
@@ -0,0 +1,109 @@
+package keyring
+
+import (
+	"context"
+	"sync"
+)
+
+// KeyringContext is a Keyring with context.Context for cancellation and
+// deadlines, see WithContext.
+type KeyringContext interface {
+	// Get bytes.
+	Get(ctx context.Context, id string) ([]byte, error)
+	// Set bytes.
+	Set(ctx context.Context, id string, data []byte) error
+	// Delete bytes.
+	Delete(ctx context.Context, id string) (bool, error)
+	// Exists returns true if exists.
+	Exists(ctx context.Context, id string) (bool, error)
+	// Items with prefix.
+	Items(ctx context.Context, prefix string) ([]*Item, error)
+}
+
+// WithContext returns a KeyringContext for kr.
+// If the context is cancelled or its deadline is exceeded before an operation
+// finishes, the context error is returned without waiting. The backends
+// (for example, the system keyring) can't be interrupted, so an operation that
+// already started still completes in the background: a cancelled Set or
+// Delete may still be applied.
+// Operations on kr are serialized, so a later operation waits for an earlier
+// (cancelled) one to finish, and an operation cancelled before it started
+// isn't run.
+func WithContext(kr Keyring) KeyringContext {
+	return &withContext{kr: kr}
+}
+
+type withContext struct {
+	kr Keyring
+	// mtx so operations on kr (including those still running after a cancel)
+	// are serialized.
+	mtx sync.Mutex
+}
+
+type result struct {
+	b     []byte
+	ok    bool
+	items []*Item
+	err   error
+}
+
+func (k *withContext) do(ctx context.Context, fn func() result) result {
+	if err := ctx.Err(); err != nil {
+		return result{err: err}
+	}
+	ch := make(chan result, 1)
+	go func() {
+		k.mtx.Lock()
+		defer k.mtx.Unlock()
+		if err := ctx.Err(); err != nil {
+			ch <- result{err: err}
+			return
+		}
+		ch <- fn()
+	}()
+	select {
+	case <-ctx.Done():
+		return result{err: ctx.Err()}
+	case r := <-ch:
+		return r
+	}
+}
+
+func (k *withContext) Get(ctx context.Context, id string) ([]byte, error) {
+	r := k.do(ctx, func() result {
+		b, err := k.kr.Get(id)
+		return result{b: b, err: err}
+	})
+	return r.b, r.err
+}
+
+func (k *withContext) Set(ctx context.Context, id string, data []byte) error {
+	r := k.do(ctx, func() result {
+		return result{err: k.kr.Set(id, data)}
+	})
+	return r.err
+}
+
+func (k *withContext) Delete(ctx context.Context, id string) (bool, error) {
+	r := k.do(ctx, func() result {
+		ok, err := k.kr.Delete(id)
+		return result{ok: ok, err: err}
+	})
+	return r.ok, r.err
+}
+
+func (k *withContext) Exists(ctx context.Context, id string) (bool, error) {
+	r := k.do(ctx, func() result {
+		ok, err := k.kr.Exists(id)
+		return result{ok: ok, err: err}
+	})
+	return r.ok, r.err
+}
+
+func (k *withContext) Items(ctx context.Context, prefix string) ([]*Item, error) {
+	r := k.do(ctx, func() result {
+		items, err := k.kr.Items(prefix)
+		return result{items: items, err: err}
+	})
+	return r.items, r.err
+}
@@ -0,0 +1,80 @@
+package keyring_test
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/keys-pub/keys/keyring"
+	"github.com/stretchr/testify/require"
+)
+
+type slowBackend struct {
+	keyring.ExternalBackend
+	wait chan struct{}
+}
+
+func (b *slowBackend) Get(path string) ([]byte, error) {
+	<-b.wait
+	return b.ExternalBackend.Get(path)
+}
+
+func (b *slowBackend) Set(path string, data []byte) error {
+	<-b.wait
+	return b.ExternalBackend.Set(path, data)
+}
+
+func TestWithContext(t *testing.T) {
+	kr := keyring.WithContext(keyring.NewMem())
+	ctx := context.TODO()
+
+	err := kr.Set(ctx, "a", []byte("a"))
+	require.NoError(t, err)
+	b, err := kr.Get(ctx, "a")
+	require.NoError(t, err)
+	require.Equal(t, []byte("a"), b)
+	ok, err := kr.Exists(ctx, "a")
+	require.NoError(t, err)
+	require.True(t, ok)
+	items, err := kr.Items(ctx, "")
+	require.NoError(t, err)
+	require.Equal(t, 1, len(items))
+	ok, err = kr.Delete(ctx, "a")
+	require.NoError(t, err)
+	require.True(t, ok)
+
+	cctx, cancel := context.WithCancel(ctx)
+	cancel()
+	_, err = kr.Get(cctx, "a")
+	require.Equal(t, context.Canceled, err)
+}
+
+func TestWithContextDeadline(t *testing.T) {
+	backend := &slowBackend{ExternalBackend: newTestBackend(), wait: make(chan struct{})}
+	defer close(backend.wait)
+	kr := keyring.WithContext(keyring.NewExternal(backend))
+
+	ctx, cancel := context.WithTimeout(context.TODO(), time.Millisecond*10)
+	defer cancel()
+	_, err := kr.Get(ctx, "a")
+	require.Equal(t, context.DeadlineExceeded, err)
+}
+
+func TestWithContextCancelledWrite(t *testing.T) {
+	backend := &slowBackend{ExternalBackend: newTestBackend(), wait: make(chan struct{})}
+	kr := keyring.WithContext(keyring.NewExternal(backend))
+
+	ctx, cancel := context.WithTimeout(context.TODO(), time.Millisecond*10)
+	defer cancel()
+	err := kr.Set(ctx, "a", []byte("a"))
+	require.Equal(t, context.DeadlineExceeded, err)
+
+	// Get waits for the (cancelled) Set, which is still applied
+	go func() {
+		time.Sleep(time.Millisecond * 10)
+		close(backend.wait)
+	}()
+	b, err := kr.Get(context.TODO(), "a")
+	require.NoError(t, err)
+	require.Equal(t, []byte("a"), b)
+}
@@ -0,0 +1,101 @@
+package keyring
+
+import (
+	"sort"
+
+	"github.com/pkg/errors"
+)
+
+// ExternalBackend is a minimal interface for an external secret manager, such
+// as Vault or AWS Secrets Manager, to be used as a Keyring with NewExternal.
+type ExternalBackend interface {
+	// Get data at path, or nil if not found.
+	Get(path string) ([]byte, error)
+	// Set data at path.
+	Set(path string, data []byte) error
+	// Delete path, returning true if it existed.
+	Delete(path string) (bool, error)
+	// List paths with prefix.
+	List(prefix string) ([]string, error)
+}
+
+// ExternalResetter is an optional interface for an ExternalBackend that can
+// remove all data in a single operation. Otherwise Reset deletes each path.
+type ExternalResetter interface {
+	// Reset removes all data.
+	Reset() error
+}
+
+// NewExternal returns Keyring backed by an external secret manager.
+// Items are stored as is, so encrypt data before Set if the backend shouldn't
+// see it.
+func NewExternal(backend ExternalBackend) Keyring {
+	return &external{backend: backend}
+}
+
+type external struct {
+	backend ExternalBackend
+}
+
+func (k *external) Name() string {
+	return "external"
+}
+
+func (k *external) Get(id string) ([]byte, error) {
+	if id == "" {
+		return nil, errors.Errorf("invalid id")
+	}
+	return k.backend.Get(id)
+}
+
+func (k *external) Set(id string, data []byte) error {
+	if id == "" {
+		return errors.Errorf("invalid id")
+	}
+	return k.backend.Set(id, data)
+}
+
+func (k *external) Delete(id string) (bool, error) {
+	if id == "" {
+		return false, errors.Errorf("invalid id")
+	}
+	return k.backend.Delete(id)
+}
+
+func (k *external) Exists(id string) (bool, error) {
+	if id == "" {
+		return false, errors.Errorf("invalid id")
+	}
+	b, err := k.backend.Get(id)
+	if err != nil {
+		return false, err
+	}
+	return b != nil, nil
+}
+
+func (k *external) Reset() error {
+	if r, ok := k.backend.(ExternalResetter); ok {
+		return r.Reset()
+	}
+	return reset(k)
+}
+
+func (k *external) Items(prefix string) ([]*Item, error) {
+	paths, err := k.backend.List(prefix)
+	if err != nil {
+		return nil, err
+	}
+	sort.Strings(paths)
+	out := make([]*Item, 0, len(paths))
+	for _, path := range paths {
+		b, err := k.backend.Get(path)
+		if err != nil {
+			return nil, err
+		}
+		if b == nil {
+			continue
+		}
+		out = append(out, &Item{ID: path, Data: b})
+	}
+	return out, nil
+}
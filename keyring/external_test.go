@@ -0,0 +1,71 @@
+package keyring_test
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/keys-pub/keys/keyring"
+	"github.com/stretchr/testify/require"
+)
+
+type testBackend struct {
+	paths map[string][]byte
+}
+
+func newTestBackend() *testBackend {
+	return &testBackend{paths: map[string][]byte{}}
+}
+
+func (b *testBackend) Get(path string) ([]byte, error) {
+	return b.paths[path], nil
+}
+
+func (b *testBackend) Set(path string, data []byte) error {
+	b.paths[path] = data
+	return nil
+}
+
+func (b *testBackend) Delete(path string) (bool, error) {
+	_, ok := b.paths[path]
+	delete(b.paths, path)
+	return ok, nil
+}
+
+func (b *testBackend) List(prefix string) ([]string, error) {
+	out := []string{}
+	for path := range b.paths {
+		if strings.HasPrefix(path, prefix) {
+			out = append(out, path)
+		}
+	}
+	return out, nil
+}
+
+func TestExternal(t *testing.T) {
+	testKeyring(t, keyring.NewExternal(newTestBackend()))
+}
+
+func TestExternalReset(t *testing.T) {
+	testReset(t, keyring.NewExternal(newTestBackend()))
+}
+
+type testResetBackend struct {
+	*testBackend
+	resets int
+}
+
+func (b *testResetBackend) Reset() error {
+	b.resets++
+	b.paths = map[string][]byte{}
+	return nil
+}
+
+func TestExternalResetter(t *testing.T) {
+	backend := &testResetBackend{testBackend: newTestBackend()}
+	testReset(t, keyring.NewExternal(backend))
+	require.Equal(t, 1, backend.resets)
+}
+
+func TestExternalDocuments(t *testing.T) {
+	testDocuments(t, keyring.NewExternal(newTestBackend()))
+}
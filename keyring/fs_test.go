@@ -3,6 +3,7 @@ package keyring_test
 import (
 	"io/ioutil"
 	"os"
+	"path/filepath"
 	"testing"
 
 	"github.com/keys-pub/keys/keyring"
@@ -42,3 +43,63 @@ func TestFSDocuments(t *testing.T) {
 	defer closeFn()
 	testDocuments(t, st)
 }
+
+func TestFSResetRemovesDir(t *testing.T) {
+	dir, err := ioutil.TempDir("", "KeysTest.")
+	require.NoError(t, err)
+	defer os.RemoveAll(dir)
+	st, err := keyring.NewFS(filepath.Join(dir, "service"))
+	require.NoError(t, err)
+
+	err = st.Set("key1", []byte("value1"))
+	require.NoError(t, err)
+	err = st.Set(".hidden", []byte("value2"))
+	require.NoError(t, err)
+	err = st.Set("#reserved", []byte("value3"))
+	require.NoError(t, err)
+	ids, err := keyring.IDs(st, "")
+	require.NoError(t, err)
+	require.Equal(t, []string{"#reserved", ".hidden", "key1"}, ids)
+
+	err = st.Reset()
+	require.NoError(t, err)
+	_, err = os.Stat(filepath.Join(dir, "service"))
+	require.True(t, os.IsNotExist(err))
+
+	ids, err = keyring.IDs(st, "")
+	require.NoError(t, err)
+	require.Equal(t, []string{}, ids)
+}
+
+func TestMigrate(t *testing.T) {
+	src := keyring.NewMem()
+	err := src.Set("key1", []byte("value1"))
+	require.NoError(t, err)
+	err = src.Set(".hidden", []byte("value2"))
+	require.NoError(t, err)
+	err = src.Set("#reserved", []byte("value3"))
+	require.NoError(t, err)
+
+	dst, closeFn := testFS(t)
+	defer closeFn()
+	err = dst.Set("key1", []byte("old"))
+	require.NoError(t, err)
+	err = dst.Set("other", []byte("other"))
+	require.NoError(t, err)
+
+	n, err := keyring.Migrate(src, dst)
+	require.NoError(t, err)
+	require.Equal(t, 3, n)
+
+	ids, err := keyring.IDs(dst, "")
+	require.NoError(t, err)
+	require.Equal(t, []string{"#reserved", ".hidden", "key1", "other"}, ids)
+	out, err := dst.Get("key1")
+	require.NoError(t, err)
+	require.Equal(t, []byte("value1"), out)
+
+	// Again
+	n, err = keyring.Migrate(src, dst)
+	require.NoError(t, err)
+	require.Equal(t, 0, n)
+}
@@ -0,0 +1,59 @@
+package keyring
+
+// Hooks are callbacks for keyring operations, for example, for metrics or
+// audit logs. Callbacks are only passed item ids and outcomes, never item
+// data. Any callback can be nil.
+type Hooks struct {
+	// OnGet is called after Get, with whether the item was found, or the
+	// error if Get failed, so failed and not found reads are reported.
+	OnGet func(id string, found bool, err error)
+	// OnSet is called after Set, with whether it succeeded.
+	OnSet func(id string, ok bool)
+	// OnDelete is called after a successful Delete, with whether the item
+	// existed.
+	OnDelete func(id string, found bool)
+	// OnReset is called after a successful Reset.
+	OnReset func()
+}
+
+// WithHooks returns a Keyring that calls hooks for operations on kr.
+func WithHooks(kr Keyring, hooks Hooks) Keyring {
+	return &hooked{Keyring: kr, hooks: hooks}
+}
+
+type hooked struct {
+	Keyring
+	hooks Hooks
+}
+
+func (k *hooked) Get(id string) ([]byte, error) {
+	b, err := k.Keyring.Get(id)
+	if k.hooks.OnGet != nil {
+		k.hooks.OnGet(id, err == nil && b != nil, err)
+	}
+	return b, err
+}
+
+func (k *hooked) Set(id string, data []byte) error {
+	err := k.Keyring.Set(id, data)
+	if k.hooks.OnSet != nil {
+		k.hooks.OnSet(id, err == nil)
+	}
+	return err
+}
+
+func (k *hooked) Delete(id string) (bool, error) {
+	ok, err := k.Keyring.Delete(id)
+	if err == nil && k.hooks.OnDelete != nil {
+		k.hooks.OnDelete(id, ok)
+	}
+	return ok, err
+}
+
+func (k *hooked) Reset() error {
+	err := k.Keyring.Reset()
+	if err == nil && k.hooks.OnReset != nil {
+		k.hooks.OnReset()
+	}
+	return err
+}
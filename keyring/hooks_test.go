@@ -0,0 +1,61 @@
+package keyring_test
+
+import (
+	"strconv"
+	"testing"
+
+	"github.com/keys-pub/keys/keyring"
+	"github.com/stretchr/testify/require"
+)
+
+func TestWithHooks(t *testing.T) {
+	events := []string{}
+	kr := keyring.WithHooks(keyring.NewMem(), keyring.Hooks{
+		OnGet: func(id string, found bool, err error) {
+			if err != nil {
+				events = append(events, "get:"+id+":"+err.Error())
+				return
+			}
+			events = append(events, "get:"+id+":"+strconv.FormatBool(found))
+		},
+		OnSet: func(id string, ok bool) {
+			events = append(events, "set:"+id+":"+strconv.FormatBool(ok))
+		},
+		OnDelete: func(id string, found bool) {
+			events = append(events, "delete:"+id+":"+strconv.FormatBool(found))
+		},
+		OnReset: func() {
+			events = append(events, "reset")
+		},
+	})
+	require.Equal(t, "mem", kr.Name())
+
+	err := kr.Set("a", []byte("secret"))
+	require.NoError(t, err)
+	b, err := kr.Get("a")
+	require.NoError(t, err)
+	require.Equal(t, []byte("secret"), b)
+	_, err = kr.Get("b")
+	require.NoError(t, err)
+	_, err = kr.Get("")
+	require.EqualError(t, err, "invalid id")
+	err = kr.Set("", []byte("invalid"))
+	require.EqualError(t, err, "invalid id")
+	_, err = kr.Delete("a")
+	require.NoError(t, err)
+	_, err = kr.Delete("a")
+	require.NoError(t, err)
+	err = kr.Reset()
+	require.NoError(t, err)
+
+	require.Equal(t, []string{
+		"set:a:true",
+		"get:a:true",
+		"get:b:false",
+		"get::invalid id",
+		"set::false",
+		"delete:a:true",
+		"delete:a:false",
+		"reset",
+	}, events)
+}
@@ -0,0 +1,261 @@
+package keyring
+
+import (
+	"bytes"
+	"crypto/subtle"
+	"encoding/json"
+	"io"
+	"io/ioutil"
+	"net/http"
+	"net/url"
+	"strings"
+	"sync"
+
+	"github.com/pkg/errors"
+)
+
+// maxHTTPItemSize is the maximum item size for the HTTP keyring.
+const maxHTTPItemSize = 1024 * 1024
+
+// HTTPOptions are options for the HTTP keyring and handler.
+type HTTPOptions struct {
+	// Token for "Authorization: Bearer" auth.
+	Token string
+}
+
+// HTTPOption ...
+type HTTPOption func(*HTTPOptions)
+
+func newHTTPOptions(opts ...HTTPOption) HTTPOptions {
+	var options HTTPOptions
+	for _, o := range opts {
+		o(&options)
+	}
+	return options
+}
+
+// WithToken option, to use (or require) a bearer token.
+func WithToken(token string) HTTPOption {
+	return func(o *HTTPOptions) {
+		o.Token = token
+	}
+}
+
+// NewHTTP returns a Keyring using a remote keyring at baseURL, served by
+// KeyringHandler.
+// Items are sent as is, so encrypt data before Set if the server shouldn't see
+// it.
+func NewHTTP(baseURL string, client *http.Client, opt ...HTTPOption) Keyring {
+	if client == nil {
+		client = http.DefaultClient
+	}
+	opts := newHTTPOptions(opt...)
+	return NewExternal(&httpBackend{
+		baseURL: strings.TrimSuffix(baseURL, "/"),
+		client:  client,
+		token:   opts.Token,
+	})
+}
+
+type httpBackend struct {
+	baseURL string
+	client  *http.Client
+	token   string
+}
+
+func (b *httpBackend) do(method string, path string, params url.Values, body []byte) (int, []byte, error) {
+	urs := b.baseURL + path + "?" + params.Encode()
+	var r io.Reader
+	if body != nil {
+		r = bytes.NewReader(body)
+	}
+	req, err := http.NewRequest(method, urs, r)
+	if err != nil {
+		return 0, nil, err
+	}
+	if b.token != "" {
+		req.Header.Set("Authorization", "Bearer "+b.token)
+	}
+	resp, err := b.client.Do(req)
+	if err != nil {
+		return 0, nil, err
+	}
+	defer resp.Body.Close()
+	out, err := ioutil.ReadAll(io.LimitReader(resp.Body, maxHTTPItemSize+1))
+	if err != nil {
+		return 0, nil, err
+	}
+	if len(out) > maxHTTPItemSize {
+		return 0, nil, errors.Errorf("response too large")
+	}
+	switch resp.StatusCode {
+	case http.StatusOK, http.StatusNotFound:
+		return resp.StatusCode, out, nil
+	default:
+		return 0, nil, errors.Errorf("keyring http error %d", resp.StatusCode)
+	}
+}
+
+func (b *httpBackend) Get(path string) ([]byte, error) {
+	status, out, err := b.do("GET", "/item", url.Values{"id": []string{path}}, nil)
+	if err != nil {
+		return nil, err
+	}
+	if status == http.StatusNotFound {
+		return nil, nil
+	}
+	return out, nil
+}
+
+func (b *httpBackend) Set(path string, data []byte) error {
+	if data == nil {
+		data = []byte{}
+	}
+	_, _, err := b.do("PUT", "/item", url.Values{"id": []string{path}}, data)
+	return err
+}
+
+func (b *httpBackend) Delete(path string) (bool, error) {
+	status, _, err := b.do("DELETE", "/item", url.Values{"id": []string{path}}, nil)
+	if err != nil {
+		return false, err
+	}
+	return status == http.StatusOK, nil
+}
+
+func (b *httpBackend) List(prefix string) ([]string, error) {
+	_, out, err := b.do("GET", "/items", url.Values{"prefix": []string{prefix}}, nil)
+	if err != nil {
+		return nil, err
+	}
+	var ids []string
+	if err := json.Unmarshal(out, &ids); err != nil {
+		return nil, err
+	}
+	return ids, nil
+}
+
+func (b *httpBackend) Reset() error {
+	_, _, err := b.do("DELETE", "/items", url.Values{}, nil)
+	return err
+}
+
+// KeyringHandler serves a Keyring over HTTP, for use with NewHTTP.
+//
+//	GET /item?id=  returns the item data (404 if not found)
+//	PUT /item?id=  sets the item data (request body)
+//	DELETE /item?id=  deletes the item (404 if not found)
+//	GET /items?prefix=  returns a JSON array of item ids
+//	DELETE /items  removes all items (Reset)
+//
+// If WithToken is specified, requests need a matching bearer token.
+// Keyring implementations aren't safe for concurrent use, so requests access
+// kr one at a time.
+func KeyringHandler(kr Keyring, opt ...HTTPOption) http.Handler {
+	opts := newHTTPOptions(opt...)
+	return &keyringHandler{kr: kr, token: opts.Token}
+}
+
+type keyringHandler struct {
+	kr    Keyring
+	token string
+	// mtx for kr, since requests are handled concurrently.
+	mtx sync.Mutex
+}
+
+func (h *keyringHandler) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	if h.token != "" {
+		auth := []byte(r.Header.Get("Authorization"))
+		if subtle.ConstantTimeCompare(auth, []byte("Bearer "+h.token)) != 1 {
+			http.Error(w, "unauthorized", http.StatusUnauthorized)
+			return
+		}
+	}
+	switch r.URL.Path {
+	case "/item":
+		h.serveItem(w, r)
+	case "/items":
+		switch r.Method {
+		case "GET":
+		case "DELETE":
+			h.mtx.Lock()
+			defer h.mtx.Unlock()
+			if err := h.kr.Reset(); err != nil {
+				http.Error(w, err.Error(), http.StatusInternalServerError)
+			}
+			return
+		default:
+			http.Error(w, "method not allowed", http.StatusMethodNotAllowed)
+			return
+		}
+		h.mtx.Lock()
+		ids, err := IDs(h.kr, r.URL.Query().Get("prefix"))
+		h.mtx.Unlock()
+		if err != nil {
+			http.Error(w, err.Error(), http.StatusInternalServerError)
+			return
+		}
+		writeJSON(w, ids)
+	default:
+		http.NotFound(w, r)
+	}
+}
+
+func (h *keyringHandler) serveItem(w http.ResponseWriter, r *http.Request) {
+	id := r.URL.Query().Get("id")
+	if id == "" {
+		http.Error(w, "invalid id", http.StatusBadRequest)
+		return
+	}
+	switch r.Method {
+	case "GET":
+		h.mtx.Lock()
+		b, err := h.kr.Get(id)
+		h.mtx.Unlock()
+		if err != nil {
+			http.Error(w, err.Error(), http.StatusInternalServerError)
+			return
+		}
+		if b == nil {
+			http.NotFound(w, r)
+			return
+		}
+		_, _ = w.Write(b)
+	case "PUT":
+		b, err := ioutil.ReadAll(http.MaxBytesReader(w, r.Body, maxHTTPItemSize))
+		if err != nil {
+			http.Error(w, err.Error(), http.StatusBadRequest)
+			return
+		}
+		h.mtx.Lock()
+		defer h.mtx.Unlock()
+		if err := h.kr.Set(id, b); err != nil {
+			http.Error(w, err.Error(), http.StatusInternalServerError)
+			return
+		}
+	case "DELETE":
+		h.mtx.Lock()
+		ok, err := h.kr.Delete(id)
+		h.mtx.Unlock()
+		if err != nil {
+			http.Error(w, err.Error(), http.StatusInternalServerError)
+			return
+		}
+		if !ok {
+			http.NotFound(w, r)
+			return
+		}
+	default:
+		http.Error(w, "method not allowed", http.StatusMethodNotAllowed)
+	}
+}
+
+func writeJSON(w http.ResponseWriter, i interface{}) {
+	b, err := json.Marshal(i)
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+		return
+	}
+	w.Header().Set("Content-Type", "application/json")
+	_, _ = w.Write(b)
+}
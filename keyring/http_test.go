@@ -0,0 +1,68 @@
+package keyring_test
+
+import (
+	"net/http/httptest"
+	"strconv"
+	"sync"
+	"testing"
+
+	"github.com/keys-pub/keys/keyring"
+	"github.com/stretchr/testify/require"
+)
+
+func TestHTTP(t *testing.T) {
+	server := httptest.NewServer(keyring.KeyringHandler(keyring.NewMem(), keyring.WithToken("testtoken")))
+	defer server.Close()
+	kr := keyring.NewHTTP(server.URL, server.Client(), keyring.WithToken("testtoken"))
+	testKeyring(t, kr)
+}
+
+func TestHTTPReset(t *testing.T) {
+	server := httptest.NewServer(keyring.KeyringHandler(keyring.NewMem()))
+	defer server.Close()
+	testReset(t, keyring.NewHTTP(server.URL, server.Client()))
+}
+
+func TestHTTPDocuments(t *testing.T) {
+	server := httptest.NewServer(keyring.KeyringHandler(keyring.NewMem()))
+	defer server.Close()
+	testDocuments(t, keyring.NewHTTP(server.URL, server.Client()))
+}
+
+func TestHTTPUnauthorized(t *testing.T) {
+	server := httptest.NewServer(keyring.KeyringHandler(keyring.NewMem(), keyring.WithToken("testtoken")))
+	defer server.Close()
+
+	kr := keyring.NewHTTP(server.URL, server.Client())
+	_, err := kr.Get("a")
+	require.EqualError(t, err, "keyring http error 401")
+
+	kr = keyring.NewHTTP(server.URL, server.Client(), keyring.WithToken("invalid"))
+	err = kr.Set("a", []byte("a"))
+	require.EqualError(t, err, "keyring http error 401")
+}
+
+func TestHTTPConcurrent(t *testing.T) {
+	server := httptest.NewServer(keyring.KeyringHandler(keyring.NewMem()))
+	defer server.Close()
+	kr := keyring.NewHTTP(server.URL, server.Client())
+
+	var wg sync.WaitGroup
+	for i := 0; i < 10; i++ {
+		wg.Add(1)
+		go func(i int) {
+			defer wg.Done()
+			id := strconv.Itoa(i)
+			require.NoError(t, kr.Set(id, []byte(id)))
+			_, err := kr.Get(id)
+			require.NoError(t, err)
+			_, err = keyring.IDs(kr, "")
+			require.NoError(t, err)
+		}(i)
+	}
+	wg.Wait()
+
+	ids, err := keyring.IDs(kr, "")
+	require.NoError(t, err)
+	require.Equal(t, 10, len(ids))
+}
@@ -2,6 +2,9 @@
 package keyring
 
 import (
+	"bytes"
+	"sort"
+
 	"github.com/pkg/errors"
 )
 
@@ -19,6 +22,22 @@ type Item struct {
 	Data []byte
 }
 
+// ErrItemNotFound if an item wasn't found.
+var ErrItemNotFound = errors.New("keyring item not found")
+
+// Wipe zeros the item data.
+func (i *Item) Wipe() {
+	Zero(i.Data)
+}
+
+// Zero overwrites b with zeros, for example, to clear secret data from memory
+// after use.
+func Zero(b []byte) {
+	for i := range b {
+		b[i] = 0
+	}
+}
+
 // Keyring is the interface used to store data.
 type Keyring interface {
 	// Name of the keyring implementation.
@@ -53,7 +72,87 @@ func IDs(kr Keyring, prefix string) ([]string, error) {
 	return paths, nil
 }
 
-var _ = reset
+// Range calls fn for every item in the Keyring, in item ID order.
+// All items are included, regardless of prefix (including "hidden" or
+// "reserved" IDs, such as those starting with "." or "#").
+// If fn returns an error, iteration stops and that error is returned.
+func Range(kr Keyring, fn func(item *Item) error) error {
+	items, err := kr.Items("")
+	if err != nil {
+		return err
+	}
+	sort.Slice(items, func(i, j int) bool {
+		return items[i].ID < items[j].ID
+	})
+	for _, item := range items {
+		if err := fn(item); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// GetAll returns items for ids, by ID.
+// IDs that don't exist are omitted from the result.
+func GetAll(kr Keyring, ids []string) (map[string]*Item, error) {
+	out := make(map[string]*Item, len(ids))
+	for _, id := range ids {
+		b, err := kr.Get(id)
+		if err != nil {
+			return nil, err
+		}
+		if b == nil {
+			continue
+		}
+		out[id] = &Item{ID: id, Data: b}
+	}
+	return out, nil
+}
+
+// SetString sets a string value for an item, for example, a token.
+func SetString(kr Keyring, id string, value string) error {
+	return kr.Set(id, []byte(value))
+}
+
+// GetString returns the string value for an item (see SetString).
+// If the item wasn't found, returns ErrItemNotFound, so you can tell an item
+// that isn't set from one set to an empty string.
+func GetString(kr Keyring, id string) (string, error) {
+	b, err := kr.Get(id)
+	if err != nil {
+		return "", err
+	}
+	if b == nil {
+		return "", ErrItemNotFound
+	}
+	return string(b), nil
+}
+
+// Migrate copies all items from src to dst, returning the number of items
+// copied.
+// Items already in dst with the same data are skipped, so running it again is
+// safe. Items in dst that aren't in src are left as is.
+func Migrate(src Keyring, dst Keyring) (int, error) {
+	items, err := src.Items("")
+	if err != nil {
+		return 0, err
+	}
+	count := 0
+	for _, item := range items {
+		existing, err := dst.Get(item.ID)
+		if err != nil {
+			return count, err
+		}
+		if existing != nil && bytes.Equal(existing, item.Data) {
+			continue
+		}
+		if err := dst.Set(item.ID, item.Data); err != nil {
+			return count, err
+		}
+		count++
+	}
+	return count, nil
+}
 
 func reset(kr Keyring) error {
 	ids, err := IDs(kr, "")
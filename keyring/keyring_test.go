@@ -157,3 +157,22 @@ func testDocuments(t *testing.T, kr keyring.Keyring) {
 	require.Equal(t, "bkey1", out[0].ID)
 	require.Equal(t, []byte("bval1"), out[0].Data)
 }
+
+func TestString(t *testing.T) {
+	kr := keyring.NewMem()
+
+	_, err := keyring.GetString(kr, "token")
+	require.Equal(t, keyring.ErrItemNotFound, err)
+
+	err = keyring.SetString(kr, "token", "abc")
+	require.NoError(t, err)
+	s, err := keyring.GetString(kr, "token")
+	require.NoError(t, err)
+	require.Equal(t, "abc", s)
+
+	err = keyring.SetString(kr, "empty", "")
+	require.NoError(t, err)
+	s, err = keyring.GetString(kr, "empty")
+	require.NoError(t, err)
+	require.Equal(t, "", s)
+}
@@ -2,6 +2,7 @@ package keyring
 
 import (
 	"sort"
+	"strconv"
 	"strings"
 
 	"github.com/danieljoos/wincred"
@@ -23,13 +24,22 @@ type sys struct {
 	service string
 }
 
-func (k sys) Name() string {
-	return "wincred"
+// credBlobMax is the maximum credential blob size (CRED_MAX_CREDENTIAL_BLOB_SIZE).
+// Larger items are split across multiple credentials.
+const credBlobMax = 5 * 512
+
+// targetName for chunk n of id.
+// Chunks after the first are stored outside of the service prefix so they
+// aren't listed as items.
+func (k sys) targetName(id string, n int) string {
+	if n == 0 {
+		return k.service + "/" + id
+	}
+	return k.service + "#chunk/" + id + "/" + strconv.Itoa(n)
 }
 
-func (k sys) Get(id string) ([]byte, error) {
-	targetName := k.service + "/" + id
-	cred, err := wincred.GetGenericCredential(targetName)
+func (k sys) getChunk(id string, n int) ([]byte, error) {
+	cred, err := wincred.GetGenericCredential(k.targetName(id, n))
 	if err != nil {
 		if errors.Cause(err) == wincred.ErrElementNotFound {
 			return nil, nil
@@ -42,19 +52,17 @@ func (k sys) Get(id string) ([]byte, error) {
 	return cred.CredentialBlob, nil
 }
 
-func (k sys) Set(id string, data []byte) error {
-	targetName := k.service + "/" + id
-	cred := wincred.NewGenericCredential(targetName)
-	cred.CredentialBlob = data
+func (k sys) setChunk(id string, n int, b []byte) error {
+	cred := wincred.NewGenericCredential(k.targetName(id, n))
+	cred.CredentialBlob = b
 	if err := cred.Write(); err != nil {
 		return errors.Wrapf(err, "wincred Write failed")
 	}
 	return nil
 }
 
-func (k sys) Delete(id string) (bool, error) {
-	targetName := k.service + "/" + id
-	cred, err := wincred.GetGenericCredential(targetName)
+func (k sys) deleteChunk(id string, n int) (bool, error) {
+	cred, err := wincred.GetGenericCredential(k.targetName(id, n))
 	if err != nil {
 		if errors.Cause(err) == wincred.ErrElementNotFound {
 			return false, nil
@@ -70,6 +78,22 @@ func (k sys) Delete(id string) (bool, error) {
 	return true, nil
 }
 
+func (k sys) Name() string {
+	return "wincred"
+}
+
+func (k sys) Get(id string) ([]byte, error) {
+	return getChunks(k, id, credBlobMax)
+}
+
+func (k sys) Set(id string, data []byte) error {
+	return setChunks(k, id, data, credBlobMax)
+}
+
+func (k sys) Delete(id string) (bool, error) {
+	return deleteChunks(k, id)
+}
+
 func (k sys) Exists(id string) (bool, error) {
 	targetName := k.service + "/" + id
 	cred, err := wincred.GetGenericCredential(targetName)
@@ -0,0 +1,93 @@
+package keyring
+
+import (
+	"github.com/keys-pub/keys"
+	"github.com/keys-pub/keys/api"
+	"github.com/pkg/errors"
+	"github.com/vmihailenco/msgpack/v4"
+)
+
+// KeyIDs returns the key IDs (KIDs) of items stored by key ID.
+// Items whose ID isn't a valid key ID are skipped and item data isn't read.
+func KeyIDs(kr Keyring) ([]keys.ID, error) {
+	ids, err := IDs(kr, "")
+	if err != nil {
+		return nil, err
+	}
+	kids := []keys.ID{}
+	for _, id := range ids {
+		kid, err := keys.ParseID(id)
+		if err != nil {
+			continue
+		}
+		kids = append(kids, kid)
+	}
+	return kids, nil
+}
+
+// SaveKey stores a key (msgpack encoded api.Key) by its key ID.
+// Use api.NewKey to create an api.Key from a keys.Key.
+func SaveKey(kr Keyring, key *api.Key) error {
+	if err := key.Check(); err != nil {
+		return errors.Wrapf(err, "invalid key")
+	}
+	b, err := msgpack.Marshal(key)
+	if err != nil {
+		return err
+	}
+	return kr.Set(key.ID.String(), b)
+}
+
+// LoadKey returns a key stored by SaveKey, or nil if not found.
+// Use api.Key.As to convert it to a specific key type, for example,
+// keys.EdX25519Key.
+func LoadKey(kr Keyring, kid keys.ID) (*api.Key, error) {
+	b, err := kr.Get(kid.String())
+	if err != nil {
+		return nil, err
+	}
+	if b == nil {
+		return nil, nil
+	}
+	key, err := keyFromBytes(b)
+	if err != nil {
+		return nil, err
+	}
+	if key.ID != kid {
+		return nil, errors.Errorf("invalid key: id mismatch")
+	}
+	return key, nil
+}
+
+// Keys returns keys stored by SaveKey.
+// Items whose ID isn't a key ID, or whose data isn't a key, are skipped.
+func Keys(kr Keyring) ([]*api.Key, error) {
+	items, err := kr.Items("")
+	if err != nil {
+		return nil, err
+	}
+	out := []*api.Key{}
+	for _, item := range items {
+		kid, err := keys.ParseID(item.ID)
+		if err != nil {
+			continue
+		}
+		key, err := keyFromBytes(item.Data)
+		if err != nil || key.ID != kid {
+			continue
+		}
+		out = append(out, key)
+	}
+	return out, nil
+}
+
+func keyFromBytes(b []byte) (*api.Key, error) {
+	var key api.Key
+	if err := msgpack.Unmarshal(b, &key); err != nil {
+		return nil, errors.Errorf("invalid key")
+	}
+	if err := key.Check(); err != nil {
+		return nil, errors.Wrapf(err, "invalid key")
+	}
+	return &key, nil
+}
@@ -0,0 +1,67 @@
+package keyring_test
+
+import (
+	"bytes"
+	"testing"
+
+	"github.com/keys-pub/keys"
+	"github.com/keys-pub/keys/api"
+	"github.com/keys-pub/keys/keyring"
+	"github.com/stretchr/testify/require"
+)
+
+func TestKeyIDs(t *testing.T) {
+	kr := keyring.NewMem()
+
+	sk := keys.NewEdX25519KeyFromSeed(testSeed(0x01))
+	bk := keys.NewX25519KeyFromSeed(testSeed(0x02))
+
+	err := kr.Set(sk.ID().String(), sk.Seed()[:])
+	require.NoError(t, err)
+	err = kr.Set(bk.ID().String(), bk.Private())
+	require.NoError(t, err)
+	err = kr.Set("notakey", []byte("testdata"))
+	require.NoError(t, err)
+
+	kids, err := keyring.KeyIDs(kr)
+	require.NoError(t, err)
+	require.ElementsMatch(t, []keys.ID{sk.ID(), bk.ID()}, kids)
+}
+
+func TestSaveKey(t *testing.T) {
+	kr := keyring.NewMem()
+
+	sk := keys.NewEdX25519KeyFromSeed(testSeed(0x01))
+	bk := keys.NewX25519KeyFromSeed(testSeed(0x02))
+
+	out, err := keyring.LoadKey(kr, sk.ID())
+	require.NoError(t, err)
+	require.Nil(t, out)
+
+	err = keyring.SaveKey(kr, api.NewKey(sk).WithLabel("test"))
+	require.NoError(t, err)
+	err = keyring.SaveKey(kr, api.NewKey(bk))
+	require.NoError(t, err)
+	err = kr.Set("notakey", []byte("testdata"))
+	require.NoError(t, err)
+	err = keyring.SaveKey(kr, &api.Key{})
+	require.EqualError(t, err, "invalid key: empty id")
+
+	out, err = keyring.LoadKey(kr, sk.ID())
+	require.NoError(t, err)
+	require.Equal(t, []string{"test"}, out.Labels)
+	require.Equal(t, sk, out.AsEdX25519())
+
+	ks, err := keyring.Keys(kr)
+	require.NoError(t, err)
+	require.Equal(t, 2, len(ks))
+	kids := []keys.ID{}
+	for _, k := range ks {
+		kids = append(kids, k.ID)
+	}
+	require.ElementsMatch(t, []keys.ID{sk.ID(), bk.ID()}, kids)
+}
+
+func testSeed(b byte) *[32]byte {
+	return keys.Bytes32(bytes.Repeat([]byte{b}, 32))
+}
@@ -27,7 +27,7 @@ func (k *mem) Get(id string) ([]byte, error) {
 		return nil, errors.Errorf("invalid id")
 	}
 	if b, ok := k.items[id]; ok {
-		return b, nil
+		return copyBytes(b), nil
 	}
 	return nil, nil
 }
@@ -36,7 +36,7 @@ func (k *mem) Set(id string, data []byte) error {
 	if id == "" {
 		return errors.Errorf("invalid id")
 	}
-	k.items[id] = data
+	k.items[id] = copyBytes(data)
 	return nil
 }
 
@@ -68,7 +68,7 @@ func (k *mem) Items(prefix string) ([]*Item, error) {
 	out := make([]*Item, 0, len(k.items))
 	for id, b := range k.items {
 		if strings.HasPrefix(id, prefix) {
-			item := &Item{ID: id, Data: b}
+			item := &Item{ID: id, Data: copyBytes(b)}
 			out = append(out, item)
 		}
 	}
@@ -77,3 +77,12 @@ func (k *mem) Items(prefix string) ([]*Item, error) {
 	})
 	return out, nil
 }
+
+// copyBytes so items returned can be wiped (see Item.Wipe) without changing
+// the stored data.
+func copyBytes(b []byte) []byte {
+	if b == nil {
+		return nil
+	}
+	return append([]byte{}, b...)
+}
@@ -4,6 +4,8 @@ import (
 	"testing"
 
 	"github.com/keys-pub/keys/keyring"
+	"github.com/pkg/errors"
+	"github.com/stretchr/testify/require"
 )
 
 func TestMemKeyring(t *testing.T) {
@@ -17,3 +19,76 @@ func TestMemReset(t *testing.T) {
 func TestMemDocuments(t *testing.T) {
 	testDocuments(t, keyring.NewMem())
 }
+
+func TestMemRange(t *testing.T) {
+	kr := keyring.NewMem()
+	err := kr.Set("b", []byte("b"))
+	require.NoError(t, err)
+	err = kr.Set(".hidden", []byte("hidden"))
+	require.NoError(t, err)
+	err = kr.Set("#reserved", []byte("reserved"))
+	require.NoError(t, err)
+	err = kr.Set("a", []byte("a"))
+	require.NoError(t, err)
+
+	visited := []string{}
+	err = keyring.Range(kr, func(item *keyring.Item) error {
+		visited = append(visited, item.ID+"="+string(item.Data))
+		return nil
+	})
+	require.NoError(t, err)
+	require.Equal(t, []string{"#reserved=reserved", ".hidden=hidden", "a=a", "b=b"}, visited)
+
+	count := 0
+	err = keyring.Range(kr, func(item *keyring.Item) error {
+		count++
+		if item.ID == ".hidden" {
+			return errors.Errorf("stop")
+		}
+		return nil
+	})
+	require.EqualError(t, err, "stop")
+	require.Equal(t, 2, count)
+}
+
+func TestMemGetAll(t *testing.T) {
+	kr := keyring.NewMem()
+	err := kr.Set("a", []byte("a"))
+	require.NoError(t, err)
+	err = kr.Set("b", []byte("b"))
+	require.NoError(t, err)
+
+	items, err := keyring.GetAll(kr, []string{"a", "b", "c"})
+	require.NoError(t, err)
+	require.Equal(t, 2, len(items))
+	require.Equal(t, []byte("a"), items["a"].Data)
+	require.Equal(t, "b", items["b"].ID)
+	_, ok := items["c"]
+	require.False(t, ok)
+
+	items, err = keyring.GetAll(kr, []string{})
+	require.NoError(t, err)
+	require.Equal(t, 0, len(items))
+}
+
+func TestItemWipe(t *testing.T) {
+	kr := keyring.NewMem()
+	err := kr.Set("a", []byte("secret"))
+	require.NoError(t, err)
+
+	items, err := keyring.GetAll(kr, []string{"a"})
+	require.NoError(t, err)
+	item := items["a"]
+	data := item.Data
+	item.Wipe()
+	require.Equal(t, make([]byte, 6), data)
+
+	// Stored data is unchanged
+	b, err := kr.Get("a")
+	require.NoError(t, err)
+	require.Equal(t, []byte("secret"), b)
+
+	b = []byte{0x01, 0x02}
+	keyring.Zero(b)
+	require.Equal(t, []byte{0x00, 0x00}, b)
+}
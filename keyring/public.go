@@ -0,0 +1,80 @@
+package keyring
+
+import (
+	"strings"
+
+	"github.com/keys-pub/keys"
+	"github.com/pkg/errors"
+)
+
+// publicKeyPrefix is the item ID prefix for public keys in a PublicKeystore,
+// so they don't collide with (secret) keys stored by key ID.
+const publicKeyPrefix = "pub-"
+
+// PublicKeystore stores (other people's) public keys in a Keyring, for
+// example, as a local address book of verified keys.
+// Public keys aren't secret, so the data is stored as is.
+type PublicKeystore struct {
+	kr Keyring
+}
+
+// NewPublicKeystore creates a PublicKeystore backed by kr.
+func NewPublicKeystore(kr Keyring) *PublicKeystore {
+	return &PublicKeystore{kr: kr}
+}
+
+// Set a public key.
+func (s *PublicKeystore) Set(spk *keys.EdX25519PublicKey) error {
+	return s.kr.Set(publicKeyPrefix+spk.ID().String(), spk.Bytes())
+}
+
+// Get a public key, or nil if not found.
+func (s *PublicKeystore) Get(kid keys.ID) (*keys.EdX25519PublicKey, error) {
+	b, err := s.kr.Get(publicKeyPrefix + kid.String())
+	if err != nil {
+		return nil, err
+	}
+	if b == nil {
+		return nil, nil
+	}
+	spk, err := publicKeyFromBytes(b)
+	if err != nil {
+		return nil, err
+	}
+	if spk.ID() != kid {
+		return nil, errors.Errorf("invalid public key: id mismatch")
+	}
+	return spk, nil
+}
+
+// Delete a public key.
+func (s *PublicKeystore) Delete(kid keys.ID) (bool, error) {
+	return s.kr.Delete(publicKeyPrefix + kid.String())
+}
+
+// List public keys.
+func (s *PublicKeystore) List() ([]*keys.EdX25519PublicKey, error) {
+	items, err := s.kr.Items(publicKeyPrefix)
+	if err != nil {
+		return nil, err
+	}
+	out := make([]*keys.EdX25519PublicKey, 0, len(items))
+	for _, item := range items {
+		spk, err := publicKeyFromBytes(item.Data)
+		if err != nil {
+			return nil, err
+		}
+		if spk.ID().String() != strings.TrimPrefix(item.ID, publicKeyPrefix) {
+			return nil, errors.Errorf("invalid public key: id mismatch")
+		}
+		out = append(out, spk)
+	}
+	return out, nil
+}
+
+func publicKeyFromBytes(b []byte) (*keys.EdX25519PublicKey, error) {
+	if len(b) != 32 {
+		return nil, errors.Errorf("invalid public key")
+	}
+	return keys.NewEdX25519PublicKey(keys.Bytes32(b)), nil
+}
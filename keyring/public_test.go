@@ -0,0 +1,49 @@
+package keyring_test
+
+import (
+	"testing"
+
+	"github.com/keys-pub/keys"
+	"github.com/keys-pub/keys/keyring"
+	"github.com/stretchr/testify/require"
+)
+
+func TestPublicKeystore(t *testing.T) {
+	kr := keyring.NewMem()
+	ks := keyring.NewPublicKeystore(kr)
+
+	alice := keys.NewEdX25519KeyFromSeed(testSeed(0x01))
+	bob := keys.NewEdX25519KeyFromSeed(testSeed(0x02))
+
+	spk, err := ks.Get(alice.ID())
+	require.NoError(t, err)
+	require.Nil(t, spk)
+
+	err = ks.Set(alice.PublicKey())
+	require.NoError(t, err)
+	err = ks.Set(bob.PublicKey())
+	require.NoError(t, err)
+	// Secret key by key ID doesn't collide
+	err = kr.Set(alice.ID().String(), alice.Seed()[:])
+	require.NoError(t, err)
+
+	spk, err = ks.Get(alice.ID())
+	require.NoError(t, err)
+	require.Equal(t, alice.PublicKey(), spk)
+
+	spks, err := ks.List()
+	require.NoError(t, err)
+	require.Equal(t, 2, len(spks))
+	require.ElementsMatch(t, []keys.ID{alice.ID(), bob.ID()}, []keys.ID{spks[0].ID(), spks[1].ID()})
+
+	ok, err := ks.Delete(bob.ID())
+	require.NoError(t, err)
+	require.True(t, ok)
+	spks, err = ks.List()
+	require.NoError(t, err)
+	require.Equal(t, 1, len(spks))
+
+	b, err := kr.Get(alice.ID().String())
+	require.NoError(t, err)
+	require.Equal(t, alice.Seed()[:], b)
+}
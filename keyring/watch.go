@@ -0,0 +1,106 @@
+package keyring
+
+import (
+	"sync"
+)
+
+// EventType is the kind of keyring change.
+type EventType string
+
+const (
+	// EventSet if an item was set.
+	EventSet EventType = "set"
+	// EventDelete if an item was deleted.
+	EventDelete EventType = "delete"
+	// EventReset if the keyring was reset.
+	EventReset EventType = "reset"
+)
+
+// Event is a keyring change, see Watchable.
+// Events only include the item id, never item data.
+type Event struct {
+	Type EventType
+	// ID of the item (empty for EventReset).
+	ID string
+}
+
+// watchBufferSize is the channel buffer size for a watch.
+const watchBufferSize = 100
+
+// Watchable is a Keyring that notifies watchers of changes, see WithWatch.
+type Watchable struct {
+	Keyring
+	mtx      sync.Mutex
+	watchers map[int]chan Event
+	next     int
+}
+
+// WithWatch returns a Keyring for kr that can be watched for changes.
+// Only changes made through the returned Keyring are seen.
+func WithWatch(kr Keyring) *Watchable {
+	return &Watchable{Keyring: kr, watchers: map[int]chan Event{}}
+}
+
+// Watch returns a channel for changes (events) and a func to stop watching,
+// which closes the channel.
+// Events are sent when the change is made. If a watcher falls behind by more
+// than the channel buffer, events are dropped for that watcher, so don't block
+// on something else while receiving.
+func (k *Watchable) Watch() (<-chan Event, func()) {
+	k.mtx.Lock()
+	defer k.mtx.Unlock()
+	n := k.next
+	k.next++
+	ch := make(chan Event, watchBufferSize)
+	k.watchers[n] = ch
+	cancel := func() {
+		k.mtx.Lock()
+		defer k.mtx.Unlock()
+		if _, ok := k.watchers[n]; ok {
+			delete(k.watchers, n)
+			close(ch)
+		}
+	}
+	return ch, cancel
+}
+
+func (k *Watchable) notify(event Event) {
+	k.mtx.Lock()
+	defer k.mtx.Unlock()
+	for _, ch := range k.watchers {
+		select {
+		case ch <- event:
+		default:
+		}
+	}
+}
+
+// Set bytes.
+func (k *Watchable) Set(id string, data []byte) error {
+	if err := k.Keyring.Set(id, data); err != nil {
+		return err
+	}
+	k.notify(Event{Type: EventSet, ID: id})
+	return nil
+}
+
+// Delete bytes.
+func (k *Watchable) Delete(id string) (bool, error) {
+	ok, err := k.Keyring.Delete(id)
+	if err != nil {
+		return false, err
+	}
+	if ok {
+		k.notify(Event{Type: EventDelete, ID: id})
+	}
+	return ok, nil
+}
+
+// Reset removes all data.
+func (k *Watchable) Reset() error {
+	if err := k.Keyring.Reset(); err != nil {
+		return err
+	}
+	k.notify(Event{Type: EventReset})
+	return nil
+}
@@ -0,0 +1,44 @@
+package keyring_test
+
+import (
+	"testing"
+
+	"github.com/keys-pub/keys/keyring"
+	"github.com/stretchr/testify/require"
+)
+
+func TestWatch(t *testing.T) {
+	kr := keyring.WithWatch(keyring.NewMem())
+	ch, cancel := kr.Watch()
+	ch2, cancel2 := kr.Watch()
+
+	err := kr.Set("a", []byte("secret"))
+	require.NoError(t, err)
+	_, err = kr.Delete("a")
+	require.NoError(t, err)
+	// Not found, no event
+	_, err = kr.Delete("a")
+	require.NoError(t, err)
+	err = kr.Reset()
+	require.NoError(t, err)
+
+	expected := []keyring.Event{
+		{Type: keyring.EventSet, ID: "a"},
+		{Type: keyring.EventDelete, ID: "a"},
+		{Type: keyring.EventReset},
+	}
+	for _, e := range expected {
+		require.Equal(t, e, <-ch)
+		require.Equal(t, e, <-ch2)
+	}
+
+	cancel()
+	_, ok := <-ch
+	require.False(t, ok)
+	cancel()
+
+	err = kr.Set("b", []byte("secret"))
+	require.NoError(t, err)
+	require.Equal(t, keyring.Event{Type: keyring.EventSet, ID: "b"}, <-ch2)
+	cancel2()
+}
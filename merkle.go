@@ -0,0 +1,111 @@
+package keys
+
+import (
+	"crypto/sha256"
+	"crypto/subtle"
+
+	"github.com/pkg/errors"
+)
+
+// Merkle tree over the Sigchain statement hashes (see SigchainHash), using
+// the RFC 6962 (Certificate Transparency) leaf and node hashes.
+//
+// An inclusion proof is a list of sibling hashes from the leaf to the root,
+// each prefixed with a byte indicating if the sibling is on the left (0x00) or
+// right (0x01).
+
+const (
+	merkleLeafPrefix = 0x00
+	merkleNodePrefix = 0x01
+
+	merkleSiblingLeft  = 0x00
+	merkleSiblingRight = 0x01
+)
+
+// MerkleRoot returns the Merkle tree root hash over the statements.
+func (s *Sigchain) MerkleRoot() []byte {
+	level := s.merkleLeaves()
+	if len(level) == 0 {
+		h := sha256.Sum256([]byte{})
+		return h[:]
+	}
+	for len(level) > 1 {
+		level = merkleNextLevel(level)
+	}
+	return level[0]
+}
+
+// MerkleProof returns an inclusion proof for statement at seq, which can be
+// verified with VerifyMerkleProof against the MerkleRoot.
+func (s *Sigchain) MerkleProof(seq int) ([][]byte, error) {
+	if seq < 1 || seq > len(s.statements) {
+		return nil, errors.Errorf("invalid seq %d", seq)
+	}
+	proof := [][]byte{}
+	index := seq - 1
+	level := s.merkleLeaves()
+	for len(level) > 1 {
+		if index%2 == 1 {
+			proof = append(proof, bytesJoin([]byte{merkleSiblingLeft}, level[index-1]))
+		} else if index+1 < len(level) {
+			proof = append(proof, bytesJoin([]byte{merkleSiblingRight}, level[index+1]))
+		}
+		index = index / 2
+		level = merkleNextLevel(level)
+	}
+	return proof, nil
+}
+
+// VerifyMerkleProof returns true if the statement hash (see SigchainHash) is
+// included in the Merkle tree with root, from a proof from MerkleProof.
+func VerifyMerkleProof(root []byte, statementHash []byte, proof [][]byte) bool {
+	h := merkleLeafHash(statementHash)
+	for _, p := range proof {
+		if len(p) != 33 {
+			return false
+		}
+		switch p[0] {
+		case merkleSiblingLeft:
+			h = merkleNodeHash(p[1:], h)
+		case merkleSiblingRight:
+			h = merkleNodeHash(h, p[1:])
+		default:
+			return false
+		}
+	}
+	return subtle.ConstantTimeCompare(root, h) == 1
+}
+
+func (s *Sigchain) merkleLeaves() [][]byte {
+	leaves := make([][]byte, 0, len(s.statements))
+	for _, st := range s.statements {
+		// Statements in the Sigchain are verified, so we can skip verify (in
+		// SigchainHash).
+		h := sha256.Sum256(statementBytes(st, st.Sig, false))
+		leaves = append(leaves, merkleLeafHash(h[:]))
+	}
+	return leaves
+}
+
+// merkleNextLevel hashes pairs of nodes, promoting an odd last node.
+func merkleNextLevel(level [][]byte) [][]byte {
+	next := make([][]byte, 0, (len(level)+1)/2)
+	for i := 0; i < len(level); i += 2 {
+		if i+1 == len(level) {
+			next = append(next, level[i])
+			continue
+		}
+		next = append(next, merkleNodeHash(level[i], level[i+1]))
+	}
+	return next
+}
+
+func merkleLeafHash(b []byte) []byte {
+	h := sha256.Sum256(bytesJoin([]byte{merkleLeafPrefix}, b))
+	return h[:]
+}
+
+func merkleNodeHash(left []byte, right []byte) []byte {
+	h := sha256.Sum256(bytesJoin([]byte{merkleNodePrefix}, left, right))
+	return h[:]
+}
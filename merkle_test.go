@@ -0,0 +1,66 @@
+package keys_test
+
+import (
+	"bytes"
+	"testing"
+
+	"github.com/keys-pub/keys"
+	"github.com/keys-pub/keys/tsutil"
+	"github.com/stretchr/testify/require"
+)
+
+func TestSigchainMerkle(t *testing.T) {
+	clock := tsutil.NewTestClock()
+	sk := keys.NewEdX25519KeyFromSeed(testSeed(0x01))
+	sc := keys.NewSigchain(sk.ID())
+
+	roots := [][]byte{sc.MerkleRoot()}
+	for i := 1; i <= 5; i++ {
+		st, err := keys.NewSigchainStatement(sc, bytes.Repeat([]byte{byte(i)}, 16), sk, "test", clock.Now())
+		require.NoError(t, err)
+		err = sc.Add(st)
+		require.NoError(t, err)
+		root := sc.MerkleRoot()
+		for _, r := range roots {
+			require.NotEqual(t, r, root)
+		}
+		roots = append(roots, root)
+	}
+
+	root := sc.MerkleRoot()
+	for _, st := range sc.Statements() {
+		proof, err := sc.MerkleProof(st.Seq)
+		require.NoError(t, err)
+		hash, err := keys.SigchainHash(st)
+		require.NoError(t, err)
+		require.True(t, keys.VerifyMerkleProof(root, hash[:], proof))
+
+		// Wrong root
+		require.False(t, keys.VerifyMerkleProof(roots[3], hash[:], proof))
+	}
+
+	proof, err := sc.MerkleProof(2)
+	require.NoError(t, err)
+	hash, err := keys.SigchainHash(sc.Statements()[1])
+	require.NoError(t, err)
+
+	// Different statement
+	other, err := keys.SigchainHash(sc.Statements()[2])
+	require.NoError(t, err)
+	require.False(t, keys.VerifyMerkleProof(root, other[:], proof))
+
+	// Tampered proof
+	proof[0][5] ^= 0xFF
+	require.False(t, keys.VerifyMerkleProof(root, hash[:], proof))
+	proof[0][5] ^= 0xFF
+	proof[0][0] = 0x01
+	require.False(t, keys.VerifyMerkleProof(root, hash[:], proof))
+	proof[0][0] = 0x00
+	require.True(t, keys.VerifyMerkleProof(root, hash[:], proof))
+	require.False(t, keys.VerifyMerkleProof(root, hash[:], proof[1:]))
+
+	_, err = sc.MerkleProof(0)
+	require.EqualError(t, err, "invalid seq 0")
+	_, err = sc.MerkleProof(6)
+	require.EqualError(t, err, "invalid seq 6")
+}
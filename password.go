@@ -107,13 +107,76 @@ func randomCharFromSet(charSet string) byte {
 
 // KeyForPassword generates a key from a password and salt.
 func KeyForPassword(password string, salt []byte) (*[32]byte, error) {
+	return KeyForPasswordWithParams(password, salt, DefaultArgon2Params)
+}
+
+// Argon2Params are the Argon2id cost parameters.
+type Argon2Params struct {
+	// Time is the number of passes.
+	Time uint32 `json:"t" msgpack:"t"`
+	// Memory in KiB.
+	Memory uint32 `json:"m" msgpack:"m"`
+	// Threads is the degree of parallelism.
+	Threads uint8 `json:"p" msgpack:"p"`
+}
+
+// DefaultArgon2Params are the params used by KeyForPassword.
+var DefaultArgon2Params = Argon2Params{Time: 1, Memory: 64 * 1024, Threads: 4}
+
+// minArgon2Memory is the minimum memory (KiB) TuneArgon2 will choose.
+const minArgon2Memory = 16 * 1024
+
+// maxArgon2Time is the maximum number of passes TuneArgon2 will choose.
+const maxArgon2Time = 64
+
+// KeyForPasswordWithParams generates a key from a password and salt, with
+// Argon2id params.
+func KeyForPasswordWithParams(password string, salt []byte, params Argon2Params) (*[32]byte, error) {
 	if len(salt) < 16 {
 		return nil, errors.Errorf("not enough salt")
 	}
 	if password == "" {
 		return nil, errors.Errorf("empty password")
 	}
+	if params.Time == 0 || params.Memory == 0 || params.Threads == 0 {
+		return nil, errors.Errorf("invalid argon2 params")
+	}
 
-	akey := argon2.IDKey([]byte(password), salt[:], 1, 64*1024, 4, 32)
+	akey := argon2.IDKey([]byte(password), salt[:], params.Time, params.Memory, params.Threads, 32)
 	return Bytes32(akey), nil
 }
+
+// TuneArgon2 benchmarks Argon2id on this machine and returns params that take
+// about the target duration to derive a key.
+// It starts from DefaultArgon2Params, increasing the number of passes, or if a
+// single pass is too slow, decreasing memory (to no less than 16 MiB).
+// The params should be saved so the same key can be derived later.
+func TuneArgon2(target time.Duration) Argon2Params {
+	params := DefaultArgon2Params
+	salt := make([]byte, 16)
+	for {
+		d := benchmarkArgon2(params, salt)
+		if d > target && params.Memory/2 >= minArgon2Memory {
+			params.Memory = params.Memory / 2
+			continue
+		}
+		if d <= 0 {
+			d = 1
+		}
+		n := int64(target / d)
+		switch {
+		case n < 1:
+			n = 1
+		case n > maxArgon2Time:
+			n = maxArgon2Time
+		}
+		params.Time = uint32(n)
+		return params
+	}
+}
+
+func benchmarkArgon2(params Argon2Params, salt []byte) time.Duration {
+	start := time.Now()
+	_ = argon2.IDKey([]byte("password"), salt, params.Time, params.Memory, params.Threads, 32)
+	return time.Since(start)
+}
@@ -1,8 +1,10 @@
 package keys_test
 
 import (
+	"bytes"
 	"log"
 	"testing"
+	"time"
 
 	"github.com/keys-pub/keys"
 	"github.com/keys-pub/keys/dstore"
@@ -42,3 +44,35 @@ func ExampleRandPassword() {
 
 	// Output:
 }
+
+func TestKeyForPasswordWithParams(t *testing.T) {
+	salt := bytes.Repeat([]byte{0x01}, 16)
+	key, err := keys.KeyForPassword("password", salt)
+	require.NoError(t, err)
+	key2, err := keys.KeyForPasswordWithParams("password", salt, keys.DefaultArgon2Params)
+	require.NoError(t, err)
+	require.Equal(t, key, key2)
+
+	key3, err := keys.KeyForPasswordWithParams("password", salt, keys.Argon2Params{Time: 2, Memory: 16 * 1024, Threads: 1})
+	require.NoError(t, err)
+	require.NotEqual(t, key, key3)
+
+	_, err = keys.KeyForPasswordWithParams("password", salt, keys.Argon2Params{})
+	require.EqualError(t, err, "invalid argon2 params")
+	_, err = keys.KeyForPasswordWithParams("password", salt[:15], keys.DefaultArgon2Params)
+	require.EqualError(t, err, "not enough salt")
+}
+
+func TestTuneArgon2(t *testing.T) {
+	target := 200 * time.Millisecond
+	params := keys.TuneArgon2(target)
+	require.True(t, params.Time >= 1)
+	require.True(t, params.Memory >= 16*1024)
+
+	start := time.Now()
+	_, err := keys.KeyForPasswordWithParams("password", keys.RandBytes(16), params)
+	require.NoError(t, err)
+	d := time.Since(start)
+	// Bounded loosely so slow or busy machines don't flake.
+	require.True(t, d < target*5, "took %s", d)
+}
@@ -0,0 +1,70 @@
+package keys
+
+import (
+	"crypto/ed25519"
+	"crypto/rsa"
+	"crypto/x509"
+	"encoding/pem"
+
+	"github.com/pkg/errors"
+)
+
+// EncodeToPEM encodes the key as a PKCS#8 "PRIVATE KEY" PEM block.
+func (k *EdX25519Key) EncodeToPEM() []byte {
+	b, err := x509.MarshalPKCS8PrivateKey(ed25519.PrivateKey(k.Private()))
+	if err != nil {
+		panic(err)
+	}
+	return pem.EncodeToMemory(&pem.Block{Type: "PRIVATE KEY", Bytes: b})
+}
+
+// EncodeToPEM encodes the public key as a SPKI (PKIX) "PUBLIC KEY" PEM block.
+func (k *EdX25519PublicKey) EncodeToPEM() []byte {
+	b, err := x509.MarshalPKIXPublicKey(ed25519.PublicKey(k.Bytes()))
+	if err != nil {
+		panic(err)
+	}
+	return pem.EncodeToMemory(&pem.Block{Type: "PUBLIC KEY", Bytes: b})
+}
+
+// DecodeKeyFromPEM decodes a key from a PEM block.
+// Supports "PRIVATE KEY" (PKCS#8), "PUBLIC KEY" (SPKI) and "RSA PRIVATE KEY"
+// (PKCS#1) blocks, for EdX25519 and RSA keys.
+func DecodeKeyFromPEM(b []byte) (Key, error) {
+	block, _ := pem.Decode(b)
+	if block == nil {
+		return nil, errors.Errorf("failed to decode pem")
+	}
+	switch block.Type {
+	case "PRIVATE KEY":
+		k, err := x509.ParsePKCS8PrivateKey(block.Bytes)
+		if err != nil {
+			return nil, errors.Wrapf(err, "failed to parse private key")
+		}
+		switch k := k.(type) {
+		case ed25519.PrivateKey:
+			return NewEdX25519KeyFromPrivateKey(Bytes64(k)), nil
+		case *rsa.PrivateKey:
+			return NewRSAKey(k), nil
+		default:
+			return nil, errors.Errorf("unsupported private key type %T", k)
+		}
+	case "PUBLIC KEY":
+		k, err := x509.ParsePKIXPublicKey(block.Bytes)
+		if err != nil {
+			return nil, errors.Wrapf(err, "failed to parse public key")
+		}
+		switch k := k.(type) {
+		case ed25519.PublicKey:
+			return NewEdX25519PublicKey(Bytes32(k)), nil
+		case *rsa.PublicKey:
+			return NewRSAPublicKey(k), nil
+		default:
+			return nil, errors.Errorf("unsupported public key type %T", k)
+		}
+	case "RSA PRIVATE KEY":
+		return NewRSAKeyFromBytes(block.Bytes)
+	default:
+		return nil, errors.Errorf("unsupported pem block type %q", block.Type)
+	}
+}
@@ -0,0 +1,49 @@
+package keys
+
+import (
+	"crypto"
+	"crypto/ed25519"
+	"crypto/sha512"
+	"io"
+
+	"github.com/pkg/errors"
+)
+
+// SignReader signs the contents of a reader, without loading it all into memory.
+//
+// The stream is hashed with SHA-512 and the digest is signed with Ed25519ph
+// (prehashed Ed25519, RFC 8032). This is a different signature scheme than
+// Sign or SignDetached, so signatures from SignReader can only be verified with
+// VerifyReader (and vice versa).
+func SignReader(r io.Reader, key *EdX25519Key) ([]byte, error) {
+	digest, err := sha512Reader(r)
+	if err != nil {
+		return nil, err
+	}
+	pk := ed25519.PrivateKey(key.privateKey[:])
+	return pk.Sign(nil, digest, &ed25519.Options{Hash: crypto.SHA512})
+}
+
+// VerifyReader verifies a signature from SignReader over the contents of a
+// reader.
+func VerifyReader(r io.Reader, sig []byte, pk *EdX25519PublicKey) error {
+	if len(sig) != ed25519.SignatureSize {
+		return errors.Errorf("invalid sig bytes length")
+	}
+	digest, err := sha512Reader(r)
+	if err != nil {
+		return err
+	}
+	if err := ed25519.VerifyWithOptions(pk.publicKey[:], digest, sig, &ed25519.Options{Hash: crypto.SHA512}); err != nil {
+		return ErrVerifyFailed
+	}
+	return nil
+}
+
+func sha512Reader(r io.Reader) ([]byte, error) {
+	h := sha512.New()
+	if _, err := io.Copy(h, r); err != nil {
+		return nil, errors.Wrapf(err, "failed to read")
+	}
+	return h.Sum(nil), nil
+}
@@ -0,0 +1,40 @@
+package keys_test
+
+import (
+	"bytes"
+	"testing"
+
+	"github.com/keys-pub/keys"
+	"github.com/stretchr/testify/require"
+)
+
+func TestSignReader(t *testing.T) {
+	sk := keys.NewEdX25519KeyFromSeed(testSeed(0x01))
+
+	b := keys.RandBytes(10 * 1024 * 1024)
+
+	sig, err := keys.SignReader(bytes.NewReader(b), sk)
+	require.NoError(t, err)
+	require.Equal(t, 64, len(sig))
+
+	err = keys.VerifyReader(bytes.NewReader(b), sig, sk.PublicKey())
+	require.NoError(t, err)
+
+	// Different data
+	b[0] = b[0] ^ 0xFF
+	err = keys.VerifyReader(bytes.NewReader(b), sig, sk.PublicKey())
+	require.EqualError(t, err, "verify failed")
+
+	b[0] = b[0] ^ 0xFF
+
+	// Different key
+	sk2 := keys.NewEdX25519KeyFromSeed(testSeed(0x02))
+	err = keys.VerifyReader(bytes.NewReader(b), sig, sk2.PublicKey())
+	require.EqualError(t, err, "verify failed")
+
+	// Not compatible with SignDetached
+	msg := []byte("test message")
+	sig = sk.SignDetached(msg)
+	err = keys.VerifyReader(bytes.NewReader(msg), sig, sk.PublicKey())
+	require.EqualError(t, err, "verify failed")
+}
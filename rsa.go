@@ -4,7 +4,11 @@ import (
 	"crypto"
 	"crypto/rand"
 	"crypto/rsa"
+	"crypto/sha256"
 	"crypto/x509"
+	"encoding/pem"
+
+	"github.com/pkg/errors"
 )
 
 // RSA key type.
@@ -122,3 +126,74 @@ func GenerateRSAKey() *RSAKey {
 	}
 	return NewRSAKey(priv)
 }
+
+// GenerateRSAKeyWithBits generates a RSA key of bits size (at least 2048).
+func GenerateRSAKeyWithBits(bits int) (*RSAKey, error) {
+	if bits < 2048 {
+		return nil, errors.Errorf("invalid rsa key size %d", bits)
+	}
+	priv, err := rsa.GenerateKey(rand.Reader, bits)
+	if err != nil {
+		return nil, err
+	}
+	return NewRSAKey(priv), nil
+}
+
+// RSASignatureScheme is a RSA signature scheme.
+type RSASignatureScheme string
+
+const (
+	// RSAPSS is RSASSA-PSS.
+	RSAPSS RSASignatureScheme = "pss"
+	// RSAPKCS1v15 is RSASSA-PKCS1-v1_5.
+	RSAPKCS1v15 RSASignatureScheme = "pkcs1v15"
+)
+
+// Sign bytes (SHA-256 digest) with signature scheme, returning the signature.
+func (k *RSAKey) Sign(b []byte, scheme RSASignatureScheme) ([]byte, error) {
+	h := sha256.Sum256(b)
+	switch scheme {
+	case RSAPSS:
+		return rsa.SignPSS(rand.Reader, k.privateKey, crypto.SHA256, h[:], nil)
+	case RSAPKCS1v15:
+		return rsa.SignPKCS1v15(rand.Reader, k.privateKey, crypto.SHA256, h[:])
+	default:
+		return nil, errors.Errorf("unsupported rsa signature scheme %q", scheme)
+	}
+}
+
+// Verify signature for bytes (SHA-256 digest) with signature scheme.
+func (k *RSAPublicKey) Verify(sig []byte, b []byte, scheme RSASignatureScheme) error {
+	h := sha256.Sum256(b)
+	var err error
+	switch scheme {
+	case RSAPSS:
+		err = rsa.VerifyPSS(k.pk, crypto.SHA256, h[:], sig, nil)
+	case RSAPKCS1v15:
+		err = rsa.VerifyPKCS1v15(k.pk, crypto.SHA256, h[:], sig)
+	default:
+		return errors.Errorf("unsupported rsa signature scheme %q", scheme)
+	}
+	if err != nil {
+		return errors.Errorf("verify failed")
+	}
+	return nil
+}
+
+// EncodeToPEM encodes the key as a PKCS#8 "PRIVATE KEY" PEM block.
+func (k *RSAKey) EncodeToPEM() []byte {
+	b, err := x509.MarshalPKCS8PrivateKey(k.privateKey)
+	if err != nil {
+		panic(err)
+	}
+	return pem.EncodeToMemory(&pem.Block{Type: "PRIVATE KEY", Bytes: b})
+}
+
+// EncodeToPEM encodes the public key as a SPKI (PKIX) "PUBLIC KEY" PEM block.
+func (k *RSAPublicKey) EncodeToPEM() []byte {
+	b, err := x509.MarshalPKIXPublicKey(k.pk)
+	if err != nil {
+		panic(err)
+	}
+	return pem.EncodeToMemory(&pem.Block{Type: "PUBLIC KEY", Bytes: b})
+}
@@ -19,6 +19,44 @@ func TestRSAKey(t *testing.T) {
 	require.Equal(t, "rsa", string(pk.Type()))
 }
 
+func TestRSASignVerify(t *testing.T) {
+	key := keys.NewRSAKey(test2048RSAKey)
+	b := []byte("test message")
+
+	for _, scheme := range []keys.RSASignatureScheme{keys.RSAPSS, keys.RSAPKCS1v15} {
+		sig, err := key.Sign(b, scheme)
+		require.NoError(t, err)
+		err = key.PublicKey().Verify(sig, b, scheme)
+		require.NoError(t, err)
+		err = key.PublicKey().Verify(sig, []byte("other message"), scheme)
+		require.EqualError(t, err, "verify failed")
+	}
+
+	sig, err := key.Sign(b, keys.RSAPSS)
+	require.NoError(t, err)
+	err = key.PublicKey().Verify(sig, b, keys.RSAPKCS1v15)
+	require.EqualError(t, err, "verify failed")
+
+	_, err = key.Sign(b, "unknown")
+	require.EqualError(t, err, `unsupported rsa signature scheme "unknown"`)
+
+	_, err = keys.GenerateRSAKeyWithBits(1024)
+	require.EqualError(t, err, "invalid rsa key size 1024")
+}
+
+func TestRSAPEM(t *testing.T) {
+	key := keys.NewRSAKey(test2048RSAKey)
+
+	out, err := keys.DecodeKeyFromPEM(key.EncodeToPEM())
+	require.NoError(t, err)
+	require.Equal(t, key.ID(), out.ID())
+	require.Equal(t, key.Private(), out.Private())
+
+	out, err = keys.DecodeKeyFromPEM(key.PublicKey().EncodeToPEM())
+	require.NoError(t, err)
+	require.Equal(t, key.PublicKey(), out)
+}
+
 func fromBase10(base10 string) *big.Int {
 	i, ok := new(big.Int).SetString(base10, 10)
 	if !ok {
@@ -2,6 +2,7 @@ package keys
 
 import (
 	"bytes"
+	"crypto/subtle"
 
 	"github.com/pkg/errors"
 	"golang.org/x/crypto/argon2"
@@ -42,6 +43,35 @@ func secretBoxOpen(encrypted []byte, secretKey *[32]byte) ([]byte, error) {
 	return b, nil
 }
 
+// SecretBoxSealCommitted encrypts using a key, like SecretBoxSeal, and prepends
+// a 32 byte key commitment, so the encrypted bytes can only be opened with
+// this key (secretbox alone isn't key committing).
+// The commitment is HMAC-SHA256(key, "secretbox-commit" || nonce).
+// Use SecretBoxOpenCommitted to open.
+func SecretBoxSealCommitted(b []byte, secretKey *[32]byte) []byte {
+	nonce := Rand24()
+	encrypted := secretBoxSeal(b, nonce, secretKey)
+	return bytesJoin(secretBoxCommitment(nonce[:], secretKey), encrypted)
+}
+
+// SecretBoxOpenCommitted decrypts bytes from SecretBoxSealCommitted, checking
+// the key commitment.
+func SecretBoxOpenCommitted(encrypted []byte, secretKey *[32]byte) ([]byte, error) {
+	if len(encrypted) < 32+24 {
+		return nil, errors.Errorf("not enough bytes")
+	}
+	commitment := encrypted[:32]
+	nonce := encrypted[32:56]
+	if subtle.ConstantTimeCompare(commitment, secretBoxCommitment(nonce, secretKey)) != 1 {
+		return nil, errors.Errorf("secretbox key commitment mismatch")
+	}
+	return secretBoxOpen(encrypted[32:], secretKey)
+}
+
+func secretBoxCommitment(nonce []byte, secretKey *[32]byte) []byte {
+	return HMACSHA256(secretKey[:], bytesJoin([]byte("secretbox-commit"), nonce))
+}
+
 // EncryptWithPassword encrypts bytes with a password.
 // Uses argon2.IDKey(password, salt, 1, 64*1024, 4, 32) with 16 byte salt.
 // The salt bytes are prepended to the encrypted bytes.
@@ -7,6 +7,7 @@ import (
 	"github.com/keys-pub/keys"
 	"github.com/keys-pub/keys/encoding"
 	"github.com/stretchr/testify/require"
+	"golang.org/x/crypto/nacl/secretbox"
 )
 
 func TestSecretBox(t *testing.T) {
@@ -18,6 +19,58 @@ func TestSecretBox(t *testing.T) {
 	require.Equal(t, b, out)
 }
 
+func TestSecretBoxSizes(t *testing.T) {
+	sk := keys.Rand32()
+
+	// Empty
+	encrypted := keys.SecretBoxSeal([]byte{}, sk)
+	out, err := keys.SecretBoxOpen(encrypted, sk)
+	require.NoError(t, err)
+	require.Empty(t, out)
+
+	// Large
+	b := keys.RandBytes(10 * 1024 * 1024)
+	encrypted = keys.SecretBoxSeal(b, sk)
+	out, err = keys.SecretBoxOpen(encrypted, sk)
+	require.NoError(t, err)
+	require.Equal(t, b, out)
+
+	// Wrong key
+	_, err = keys.SecretBoxOpen(encrypted, keys.Rand32())
+	require.EqualError(t, err, "secretbox open failed")
+
+	_, err = keys.SecretBoxOpen(encrypted[:23], sk)
+	require.EqualError(t, err, "not enough bytes")
+}
+
+func TestSecretBoxCommitted(t *testing.T) {
+	sk := keys.Rand32()
+	b := []byte{0x01, 0x02, 0x03}
+	encrypted := keys.SecretBoxSealCommitted(b, sk)
+	require.Equal(t, 32+24+secretbox.Overhead+3, len(encrypted))
+	out, err := keys.SecretBoxOpenCommitted(encrypted, sk)
+	require.NoError(t, err)
+	require.Equal(t, b, out)
+
+	// Different key
+	_, err = keys.SecretBoxOpenCommitted(encrypted, keys.Rand32())
+	require.EqualError(t, err, "secretbox key commitment mismatch")
+
+	// Tampered commitment
+	encrypted[0] ^= 0xFF
+	_, err = keys.SecretBoxOpenCommitted(encrypted, sk)
+	require.EqualError(t, err, "secretbox key commitment mismatch")
+	encrypted[0] ^= 0xFF
+
+	// Tampered ciphertext
+	encrypted[len(encrypted)-1] ^= 0xFF
+	_, err = keys.SecretBoxOpenCommitted(encrypted, sk)
+	require.EqualError(t, err, "secretbox open failed")
+
+	_, err = keys.SecretBoxOpenCommitted(encrypted[:55], sk)
+	require.EqualError(t, err, "not enough bytes")
+}
+
 func TestSecretBoxSeal(t *testing.T) {
 	key := keys.Bytes32(encoding.MustDecode("1b27556473e985d462cd51197a9a46c76009549eac6474f206c4ee0844f68389", encoding.Hex))
 	iv := keys.Bytes24(encoding.MustDecode("69696ee955b62b73cd62bda875fc73d68219e0036b7a0b37", encoding.Hex))
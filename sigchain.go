@@ -3,10 +3,13 @@ package keys
 import (
 	"bytes"
 	"crypto/sha256"
+	"encoding/json"
+	"fmt"
 	"text/tabwriter"
 	"time"
 
 	"github.com/keys-pub/keys/dstore"
+	"github.com/keys-pub/keys/tsutil"
 	"github.com/pkg/errors"
 )
 
@@ -18,6 +21,65 @@ type Sigchain struct {
 	kid        ID
 	statements []*Statement
 	revokes    map[int]*Statement
+	types      map[string][]*Statement
+	limits     SigchainLimits
+	// totalBytes is the total (serialized) size of the statements.
+	totalBytes int
+	// verifyTimestamps if statement timestamps must not go backwards.
+	verifyTimestamps bool
+	// context for domain separation, see NewSigchainWithContext.
+	context string
+	// clock for new statements, see NewStatement.
+	clock tsutil.Clock
+
+	onAdd    func(st *Statement)
+	onRevoke func(seq int, revoke *Statement)
+}
+
+// ErrChainTooLong if adding a statement would exceed the Sigchain max length
+// (see SetMaxLength).
+// The error returned is an ErrSigchainLimit for "max-statements", which
+// matches ErrChainTooLong with errors.Is.
+var ErrChainTooLong = errors.New("sigchain too long")
+
+// SigchainLimits bound the size of a Sigchain, for sigchains from untrusted
+// sources. A limit of 0 means no limit.
+type SigchainLimits struct {
+	// MaxStatements is the maximum number of statements (including revokes).
+	// If exceeded, the error matches ErrChainTooLong (with errors.Is).
+	MaxStatements int
+	// MaxDataBytes is the maximum size of a statement's data.
+	MaxDataBytes int
+	// MaxTotalBytes is the maximum total size of all the statements, as
+	// serialized (see Statement.Bytes), so including signatures, cosignatures
+	// and other fields, not just data.
+	MaxTotalBytes int
+}
+
+// DefaultSigchainLimits are the limits for a new Sigchain.
+// They are generous for a user's sigchain, but bounded, so a sigchain from an
+// untrusted source can't be used to exhaust memory (for example, with millions
+// of empty statements).
+var DefaultSigchainLimits = SigchainLimits{
+	MaxStatements: 100000,
+	MaxDataBytes:  1024 * 1024,
+	MaxTotalBytes: 64 * 1024 * 1024,
+}
+
+// ErrSigchainLimit if adding a statement would exceed a SigchainLimits limit.
+type ErrSigchainLimit struct {
+	// Limit is "max-statements", "max-data-bytes" or "max-total-bytes".
+	Limit string
+	Max   int
+}
+
+func (e ErrSigchainLimit) Error() string {
+	return fmt.Sprintf("sigchain limit %s (%d) exceeded", e.Limit, e.Max)
+}
+
+// Is returns true for ErrChainTooLong if the limit is "max-statements".
+func (e ErrSigchainLimit) Is(target error) bool {
+	return target == ErrChainTooLong && e.Limit == "max-statements"
 }
 
 // NewSigchain creates an empty Sigchain.
@@ -26,9 +88,28 @@ func NewSigchain(kid ID) *Sigchain {
 		kid:        kid,
 		statements: []*Statement{},
 		revokes:    map[int]*Statement{},
+		types:      map[string][]*Statement{},
+		limits:     DefaultSigchainLimits,
 	}
 }
 
+// NewSigchainWithContext creates an empty Sigchain for a context.
+// The context is included in the signed bytes of the statements, and only
+// statements with the same context can be added, so statements from a
+// different application (using the same key) can't be replayed.
+// A Sigchain without a context (NewSigchain) only accepts statements without
+// a context.
+func NewSigchainWithContext(kid ID, context string) *Sigchain {
+	sc := NewSigchain(kid)
+	sc.context = context
+	return sc
+}
+
+// Context for the Sigchain, see NewSigchainWithContext.
+func (s *Sigchain) Context() string {
+	return s.context
+}
+
 // KID ...
 func (s *Sigchain) KID() ID {
 	return s.kid
@@ -44,16 +125,14 @@ func (s *Sigchain) Spew() *bytes.Buffer {
 	var out bytes.Buffer
 	w := new(tabwriter.Writer)
 	w.Init(&out, 0, 8, 1, ' ', 0)
-	for _, st := range s.statements {
-		key := dstore.Path("sigchain", st.URL())
-		b, err := st.Bytes()
-		if err != nil {
-			panic(err)
-		}
-		value := string(b)
-		out.Write([]byte(key))
+	entries, err := s.spew(SpewOptions{})
+	if err != nil {
+		panic(err)
+	}
+	for _, e := range entries {
+		out.Write([]byte(e.Key))
 		out.Write([]byte(" "))
-		out.Write([]byte(value))
+		out.Write(e.Statement)
 		out.Write([]byte("\n"))
 	}
 	if err := w.Flush(); err != nil {
@@ -62,6 +141,85 @@ func (s *Sigchain) Spew() *bytes.Buffer {
 	return &out
 }
 
+// SpewSig is how signatures are shown in Spew output.
+type SpewSig string
+
+const (
+	// SpewSigFull includes the full signature (default).
+	SpewSigFull SpewSig = ""
+	// SpewSigTruncate includes only the first 8 bytes of the signature.
+	SpewSigTruncate SpewSig = "truncate"
+	// SpewSigElide leaves the signature empty.
+	SpewSigElide SpewSig = "elide"
+)
+
+// SpewOptions are options for SpewJSON.
+type SpewOptions struct {
+	// Sig is how signatures are shown.
+	Sig SpewSig
+}
+
+// SpewOption ...
+type SpewOption func(*SpewOptions)
+
+func newSpewOptions(opts ...SpewOption) SpewOptions {
+	var options SpewOptions
+	for _, o := range opts {
+		o(&options)
+	}
+	return options
+}
+
+// SpewWithSig option, to truncate or elide signatures.
+func SpewWithSig(sig SpewSig) SpewOption {
+	return func(o *SpewOptions) {
+		o.Sig = sig
+	}
+}
+
+type spewEntry struct {
+	Key       string          `json:"key"`
+	Statement json.RawMessage `json:"statement"`
+}
+
+// SpewJSON returns the sigchain as a JSON array of {"key", "statement"}
+// entries, the same data as Spew.
+func (s *Sigchain) SpewJSON(opt ...SpewOption) ([]byte, error) {
+	opts := newSpewOptions(opt...)
+	entries, err := s.spew(opts)
+	if err != nil {
+		return nil, err
+	}
+	return json.Marshal(entries)
+}
+
+func (s *Sigchain) spew(opts SpewOptions) ([]spewEntry, error) {
+	entries := make([]spewEntry, 0, len(s.statements))
+	for _, st := range s.statements {
+		if err := st.verifySignature(); err != nil {
+			return nil, err
+		}
+		var sig []byte
+		switch opts.Sig {
+		case SpewSigFull:
+			sig = st.Sig
+		case SpewSigTruncate:
+			sig = st.Sig
+			if len(sig) > 8 {
+				sig = sig[:8]
+			}
+		case SpewSigElide:
+		default:
+			return nil, errors.Errorf("invalid spew sig option %q", opts.Sig)
+		}
+		entries = append(entries, spewEntry{
+			Key:       dstore.Path("sigchain", st.URL()),
+			Statement: statementBytes(st, sig, true),
+		})
+	}
+	return entries, nil
+}
+
 // LastSeq returns last signed statment seq (or 0 if no signed statements
 // exist).
 func (s *Sigchain) LastSeq() int {
@@ -71,6 +229,57 @@ func (s *Sigchain) LastSeq() int {
 	return s.statements[len(s.statements)-1].Seq
 }
 
+// Page returns up to limit statements after seq afterSeq, and the seq to use
+// as afterSeq for the next page, or 0 if there are no more statements.
+func (s *Sigchain) Page(afterSeq int, limit int) ([]*Statement, int, error) {
+	if afterSeq < 0 {
+		return nil, 0, errors.Errorf("invalid seq %d", afterSeq)
+	}
+	if limit < 1 {
+		return nil, 0, errors.Errorf("invalid limit %d", limit)
+	}
+	if afterSeq >= len(s.statements) {
+		return []*Statement{}, 0, nil
+	}
+	if limit > len(s.statements)-afterSeq {
+		limit = len(s.statements) - afterSeq
+	}
+	end := afterSeq + limit
+	// Limit capacity so appending to the page doesn't change the Sigchain.
+	page := s.statements[afterSeq:end:end]
+	next := 0
+	if end < len(s.statements) {
+		next = end
+	}
+	return page, next, nil
+}
+
+// StatementBySeq returns the statement with seq, or nil if not found.
+func (s *Sigchain) StatementBySeq(seq int) *Statement {
+	if seq < 1 || seq > len(s.statements) {
+		return nil
+	}
+	return s.statements[seq-1]
+}
+
+// StatementsRange returns statements with seq in [start, end] (inclusive),
+// including revoke statements.
+// The range is clipped to the statements in the Sigchain, so an out of range
+// start or end returns the statements that do exist (or an empty list).
+func (s *Sigchain) StatementsRange(start int, end int) []*Statement {
+	if start < 1 {
+		start = 1
+	}
+	if end > len(s.statements) {
+		end = len(s.statements)
+	}
+	if start > end {
+		return []*Statement{}
+	}
+	// Limit capacity so appending to the range doesn't change the Sigchain.
+	return s.statements[start-1 : end : end]
+}
+
 // Length of Sigchain.
 func (s *Sigchain) Length() int {
 	return len(s.statements)
@@ -84,51 +293,282 @@ func (s *Sigchain) Last() *Statement {
 	return s.statements[len(s.statements)-1]
 }
 
+// SetMaxLength sets the maximum number of statements (including revokes) that
+// can be added to the Sigchain, 0 for no limit.
+// This is the SigchainLimits MaxStatements.
+func (s *Sigchain) SetMaxLength(n int) {
+	s.limits.MaxStatements = n
+}
+
+// MaxLength is the maximum number of statements, see SetMaxLength.
+func (s *Sigchain) MaxLength() int {
+	return s.limits.MaxStatements
+}
+
+// SetLimits sets the Sigchain limits (the default is DefaultSigchainLimits).
+func (s *Sigchain) SetLimits(limits SigchainLimits) {
+	s.limits = limits
+}
+
+// Limits returns the Sigchain limits.
+func (s *Sigchain) Limits() SigchainLimits {
+	return s.limits
+}
+
+// SetClock sets the clock used for the timestamp of statements created with
+// NewStatement. If not set, the current time is used.
+func (s *Sigchain) SetClock(clock tsutil.Clock) {
+	s.clock = clock
+}
+
+// SetVerifyTimestamps, if enabled, rejects adding a statement with a timestamp
+// earlier than the previous statement's. Equal timestamps are allowed, and
+// statements without a timestamp are not checked.
+// It is disabled by default.
+func (s *Sigchain) SetVerifyTimestamps(b bool) {
+	s.verifyTimestamps = b
+}
+
+// OnAdd sets a callback, called after a statement is added to the Sigchain.
+func (s *Sigchain) OnAdd(fn func(st *Statement)) {
+	s.onAdd = fn
+}
+
+// OnRevoke sets a callback, called after a revoke statement is added to the
+// Sigchain, with the seq that was revoked.
+func (s *Sigchain) OnRevoke(fn func(seq int, revoke *Statement)) {
+	s.onRevoke = fn
+}
+
 // IsRevoked returns true if statement was revoked.
 func (s *Sigchain) IsRevoked(seq int) bool {
 	_, ok := s.revokes[seq]
 	return ok
 }
 
+// RevocationOf returns the revoke statement for seq, or nil if it isn't
+// revoked.
+func (s *Sigchain) RevocationOf(seq int) *Statement {
+	return s.revokes[seq]
+}
+
 // Add signed statement to the Sigchain.
 func (s *Sigchain) Add(st *Statement) error {
+	if err := s.add(st); err != nil {
+		return err
+	}
+	s.notify(st)
+	return nil
+}
+
+func (s *Sigchain) add(st *Statement) error {
 	if s.kid != st.KID {
 		return errors.Errorf("invalid statement kid")
 	}
 	if len(st.Data) == 0 && st.Type != "revoke" {
 		return errors.Errorf("no data")
 	}
-	if err := s.VerifyStatement(st, s.Last()); err != nil {
+	if st.Context != s.context {
+		return errors.Errorf("invalid statement context")
+	}
+	if err := s.checkLimits(st); err != nil {
+		return err
+	}
+	prev := s.Last()
+	if err := s.VerifyStatement(st, prev); err != nil {
 		return err
 	}
+	if s.verifyTimestamps {
+		if err := verifyTimestamp(st, prev); err != nil {
+			return err
+		}
+	}
 
 	if st.Revoke != 0 {
 		s.revokes[st.Revoke] = st
 	}
 	s.statements = append(s.statements, st)
+	s.totalBytes += statementSize(st)
+	if st.Type != "" {
+		s.types[st.Type] = append(s.types[st.Type], st)
+	}
+	return nil
+}
+
+func (s *Sigchain) checkLimits(st *Statement) error {
+	if s.limits.MaxStatements > 0 && len(s.statements) >= s.limits.MaxStatements {
+		return ErrSigchainLimit{Limit: "max-statements", Max: s.limits.MaxStatements}
+	}
+	if s.limits.MaxDataBytes > 0 && len(st.Data) > s.limits.MaxDataBytes {
+		return ErrSigchainLimit{Limit: "max-data-bytes", Max: s.limits.MaxDataBytes}
+	}
+	if s.limits.MaxTotalBytes > 0 && s.totalBytes+statementSize(st) > s.limits.MaxTotalBytes {
+		return ErrSigchainLimit{Limit: "max-total-bytes", Max: s.limits.MaxTotalBytes}
+	}
+	return nil
+}
+
+// statementSize is the serialized size of a statement, for MaxTotalBytes.
+func statementSize(st *Statement) int {
+	return len(statementBytes(st, st.Sig, true))
+}
+
+func verifyTimestamp(st *Statement, prev *Statement) error {
+	if prev == nil || st.Timestamp.IsZero() || prev.Timestamp.IsZero() {
+		return nil
+	}
+	if st.Timestamp.Before(prev.Timestamp) {
+		return errors.Errorf("seq %d: timestamp %d is earlier than previous %d", st.Seq, tsutil.Millis(st.Timestamp), tsutil.Millis(prev.Timestamp))
+	}
 	return nil
 }
 
+func (s *Sigchain) notify(st *Statement) {
+	if s.onAdd != nil {
+		s.onAdd(st)
+	}
+	if st.Revoke != 0 && s.onRevoke != nil {
+		s.onRevoke(st.Revoke, st)
+	}
+}
+
 // AddAll pushes signed statements to the Sigchain.
+// If any statement fails to verify, none of the statements are added.
 func (s *Sigchain) AddAll(statements []*Statement) error {
-	for _, e := range statements {
-		if err := s.Add(e); err != nil {
+	// Add to a copy, so the Sigchain is unchanged on error.
+	tmp := &Sigchain{
+		kid:        s.kid,
+		context:    s.context,
+		statements: s.statements[:len(s.statements):len(s.statements)],
+		revokes:    make(map[int]*Statement, len(s.revokes)),
+		types:      make(map[string][]*Statement, len(s.types)),
+		limits:     s.limits,
+		totalBytes: s.totalBytes,
+
+		verifyTimestamps: s.verifyTimestamps,
+	}
+	for seq, st := range s.revokes {
+		tmp.revokes[seq] = st
+	}
+	for typ, sts := range s.types {
+		tmp.types[typ] = sts[:len(sts):len(sts)]
+	}
+	for _, st := range statements {
+		if err := tmp.add(st); err != nil {
 			return err
 		}
 	}
+
+	s.statements = tmp.statements
+	s.revokes = tmp.revokes
+	s.types = tmp.types
+	s.totalBytes = tmp.totalBytes
+	for _, st := range statements {
+		s.notify(st)
+	}
 	return nil
 }
 
+// Export returns the Sigchain statements as a JSON array, in order.
+func (s *Sigchain) Export() ([]byte, error) {
+	sts := make([]json.RawMessage, 0, len(s.statements))
+	for _, st := range s.statements {
+		b, err := st.MarshalJSON()
+		if err != nil {
+			return nil, err
+		}
+		sts = append(sts, b)
+	}
+	return json.Marshal(sts)
+}
+
+// ImportSigchain creates a Sigchain from an exported JSON array of statements
+// (see Sigchain.Export).
+// The Sigchain kid is from the first statement, and each statement is verified
+// as it's added.
+// Statements must not have a context, see ImportSigchainWithContext.
+func ImportSigchain(b []byte) (*Sigchain, error) {
+	return ImportSigchainWithContext(b, "")
+}
+
+// ImportSigchainWithContext is ImportSigchain for a Sigchain with a context
+// (see NewSigchainWithContext).
+// Statements with a different context are rejected.
+func ImportSigchainWithContext(b []byte, context string) (*Sigchain, error) {
+	var raw []json.RawMessage
+	if err := json.Unmarshal(b, &raw); err != nil {
+		return nil, errors.Wrapf(err, "failed to import sigchain")
+	}
+	if len(raw) == 0 {
+		return nil, errors.Errorf("no statements")
+	}
+	sts := make([]*Statement, 0, len(raw))
+	for _, r := range raw {
+		st, err := unmarshalJSON(r)
+		if err != nil {
+			return nil, err
+		}
+		sts = append(sts, st)
+	}
+	sc := NewSigchainWithContext(sts[0].KID, context)
+	if err := sc.AddAll(sts); err != nil {
+		return nil, err
+	}
+	return sc, nil
+}
+
+// SigchainHashAlgorithm is the hash used for a Statement's prev, the SHA-256
+// of the previous statement's SigBytes.
+const SigchainHashAlgorithm = "sha256"
+
 // SigchainHash returns hash for Sigchain Statement.
+// A countersignature (see CountersignStatement) isn't included, so a statement
+// can be countersigned after it's added to the Sigchain.
 func SigchainHash(st *Statement) (*[32]byte, error) {
-	b, err := st.Bytes()
-	if err != nil {
+	if err := st.verifySignature(); err != nil {
 		return nil, err
 	}
-	h := sha256.Sum256(b)
+	h := sha256.Sum256(st.SigBytes())
 	return &h, nil
 }
 
+// SigBytes returns the bytes that are hashed for the next statement's prev
+// (see SigchainHashAlgorithm). This is the serialized statement with its
+// signature, but without a countersignature, for example:
+//
+//	{".sig":"...","data":"...","kid":"kex1...","seq":1,"ts":1234567890001,"type":"test"}
+func (s *Statement) SigBytes() []byte {
+	return statementBytes(s, s.Sig, false)
+}
+
+// PrevHash returns the hash of this statement, which is the prev of the next
+// statement in a Sigchain.
+// Unlike SigchainHash, this doesn't verify the statement.
+func (s *Statement) PrevHash() []byte {
+	h := sha256.Sum256(s.SigBytes())
+	return h[:]
+}
+
+// VerifyPrev checks that the statement prev is the hash of the previous
+// statement. If prev is nil, the statement prev should be empty.
+// This doesn't verify signatures (see Sigchain.VerifyStatement).
+func VerifyPrev(st *Statement, prev *Statement) error {
+	if prev == nil {
+		if len(st.Prev) != 0 {
+			return errors.Errorf("invalid statement previous, expected empty, got %s", st.Prev)
+		}
+		return nil
+	}
+	if len(st.Prev) == 0 {
+		return errors.Errorf("invalid statement previous empty")
+	}
+	prevHash := prev.PrevHash()
+	if !bytes.Equal(st.Prev, prevHash) {
+		return errors.Errorf("invalid statement previous, expected %x, got %x", prevHash, st.Prev)
+	}
+	return nil
+}
+
 // NewSigchainStatement creates a signed Statement to be added to the Sigchain.
 func NewSigchainStatement(sc *Sigchain, b []byte, sk *EdX25519Key, typ string, ts time.Time) (*Statement, error) {
 	if sc == nil {
@@ -137,10 +577,69 @@ func NewSigchainStatement(sc *Sigchain, b []byte, sk *EdX25519Key, typ string, t
 	if sc.KID() != sk.ID() {
 		return nil, errors.Errorf("invalid sigchain public key")
 	}
+	return newSigchainStatement(sc.Last(), b, sk, typ, ts, sc.context)
+}
 
-	seq := sc.LastSeq() + 1
+// NewStatement creates a signed Statement to be added to the Sigchain, with a
+// timestamp from the Sigchain clock (see SetClock).
+func (s *Sigchain) NewStatement(b []byte, sk *EdX25519Key, typ string) (*Statement, error) {
+	return NewSigchainStatement(s, b, sk, typ, s.now())
+}
+
+func (s *Sigchain) now() time.Time {
+	if s.clock == nil {
+		return time.Now()
+	}
+	return s.clock.Now()
+}
+
+// StatementInput is the data and type for a new Sigchain Statement, see
+// NewSigchainStatements.
+type StatementInput struct {
+	Data []byte
+	Type string
+}
+
+// NewSigchainStatements creates signed Statements, linked in order, to be
+// added to the Sigchain (with Sigchain.AddAll).
+// The Sigchain isn't changed.
+func NewSigchainStatements(sc *Sigchain, entries []StatementInput, sk *EdX25519Key, now func() time.Time) ([]*Statement, error) {
+	if sc == nil {
+		return nil, errors.Errorf("no sigchain specified")
+	}
+	if sc.KID() != sk.ID() {
+		return nil, errors.Errorf("invalid sigchain public key")
+	}
+	sts := make([]*Statement, 0, len(entries))
+	prev := sc.Last()
+	for _, entry := range entries {
+		st, err := newSigchainStatement(prev, entry.Data, sk, entry.Type, now(), sc.context)
+		if err != nil {
+			return nil, err
+		}
+		sts = append(sts, st)
+		prev = st
+	}
+	return sts, nil
+}
+
+func newSigchainStatement(prevStatement *Statement, b []byte, sk *EdX25519Key, typ string, ts time.Time, context string) (*Statement, error) {
+	st, err := unsignedSigchainStatement(prevStatement, b, sk.ID(), typ, ts, context)
+	if err != nil {
+		return nil, err
+	}
+	if err := st.Sign(sk); err != nil {
+		return nil, err
+	}
+	return st, nil
+}
+
+func unsignedSigchainStatement(prevStatement *Statement, b []byte, kid ID, typ string, ts time.Time, context string) (*Statement, error) {
+	seq := 1
+	if prevStatement != nil {
+		seq = prevStatement.Seq + 1
+	}
 
-	prevStatement := sc.Last()
 	prevHash, err := sigchainPreviousHash(prevStatement)
 	if err != nil {
 		return nil, err
@@ -150,17 +649,49 @@ func NewSigchainStatement(sc *Sigchain, b []byte, sk *EdX25519Key, typ string, t
 		prev = prevHash[:]
 	}
 
-	st := &Statement{
+	return &Statement{
 		Data:      b,
-		KID:       sk.ID(),
+		KID:       kid,
 		Seq:       seq,
 		Prev:      prev,
 		Timestamp: ts,
 		Type:      typ,
+		Context:   context,
+	}, nil
+}
+
+// NewSigchainMultiStatement creates a Statement to be added to the Sigchain,
+// signed by multiple keys.
+// The first key must be the Sigchain key, the other keys are cosigners (see
+// Statement.CoKIDs) and all must sign for the statement to verify.
+func NewSigchainMultiStatement(sc *Sigchain, b []byte, sks []*EdX25519Key, typ string, ts time.Time) (*Statement, error) {
+	if sc == nil {
+		return nil, errors.Errorf("no sigchain specified")
 	}
-	if err := st.Sign(sk); err != nil {
+	if len(sks) == 0 {
+		return nil, errors.Errorf("no keys specified")
+	}
+	if sc.KID() != sks[0].ID() {
+		return nil, errors.Errorf("invalid sigchain public key")
+	}
+	st, err := unsignedSigchainStatement(sc.Last(), b, sks[0].ID(), typ, ts, sc.context)
+	if err != nil {
+		return nil, err
+	}
+	for _, sk := range sks[1:] {
+		st.CoKIDs = append(st.CoKIDs, sk.ID())
+	}
+	if len(st.CoKIDs) != 0 {
+		st.Version = StatementVersionMultiSig
+	}
+	if err := st.Sign(sks[0]); err != nil {
 		return nil, err
 	}
+	for _, sk := range sks[1:] {
+		if err := st.Cosign(sk); err != nil {
+			return nil, err
+		}
+	}
 	return st, nil
 }
 
@@ -200,17 +731,21 @@ func NewRevokeStatement(sc *Sigchain, revoke int, sk *EdX25519Key) (*Statement,
 		return nil, errors.Errorf("invalid revoke seq %d", revoke)
 	}
 
-	prev := sc.Last()
+	return newRevokeStatement(sc.Last(), revoke, sk, sc.context)
+}
+
+func newRevokeStatement(prev *Statement, revoke int, sk *EdX25519Key, context string) (*Statement, error) {
 	prevHash, err := sigchainPreviousHash(prev)
 	if err != nil {
 		return nil, err
 	}
 	st := Statement{
-		KID:    sc.KID(),
-		Seq:    seq,
-		Prev:   prevHash[:],
-		Revoke: revoke,
-		Type:   "revoke",
+		KID:     sk.ID(),
+		Seq:     prev.Seq + 1,
+		Prev:    prevHash[:],
+		Revoke:  revoke,
+		Type:    "revoke",
+		Context: context,
 	}
 	if err := st.Sign(sk); err != nil {
 		return nil, err
@@ -230,13 +765,50 @@ func (s *Sigchain) Revoke(revoke int, sk *EdX25519Key) (*Statement, error) {
 	return st, nil
 }
 
+// RevokeRange revokes statements from start to end (inclusive) and adds the
+// revoke statements to the Sigchain.
+// Statements that are already revoked, or are revokes, are skipped.
+// If any revoke fails, none are added.
+func (s *Sigchain) RevokeRange(start int, end int, sk *EdX25519Key) ([]*Statement, error) {
+	if s.KID() != sk.ID() {
+		return nil, errors.Errorf("invalid sigchain public key")
+	}
+	if start < 1 || end < start || end > s.LastSeq() {
+		return nil, errors.Errorf("invalid revoke range %d-%d", start, end)
+	}
+	revokes := []*Statement{}
+	prev := s.Last()
+	for seq := start; seq <= end; seq++ {
+		if s.IsRevoked(seq) || s.statements[seq-1].Revoke != 0 {
+			continue
+		}
+		st, err := newRevokeStatement(prev, seq, sk, s.context)
+		if err != nil {
+			return nil, err
+		}
+		revokes = append(revokes, st)
+		prev = st
+	}
+	if err := s.AddAll(revokes); err != nil {
+		return nil, err
+	}
+	return revokes, nil
+}
+
 // VerifyStatement verifies a signed statement against a previous statement (in a
 // Sigchain).
 func (s *Sigchain) VerifyStatement(st *Statement, prev *Statement) error {
 	if st.KID != s.kid {
 		return errors.Errorf("invalid statement kid")
 	}
-	if err := st.Verify(); err != nil {
+	if st.Signer != "" {
+		if err := VerifyDelegated(st, s.Delegation(st.Signer)); err != nil {
+			return err
+		}
+	} else if err := st.Verify(); err != nil {
+		return err
+	}
+	if err := s.verifyDelegate(st); err != nil {
 		return err
 	}
 
@@ -282,6 +854,68 @@ func (s *Sigchain) VerifyStatement(st *Statement, prev *Statement) error {
 	return nil
 }
 
+// VerifyHead verifies a (head) statement and its predecessors back to the root,
+// fetching each previous statement by seq.
+// This checks kid, context, seq, prev and signature for each statement,
+// without needing the full Sigchain in memory. It doesn't check revokes against
+// the revoked statements (use Sigchain.Add for full verification).
+// Statements signed by a delegate (Signer) are rejected, since the delegation
+// (and whether it was revoked) can't be checked this way.
+// Statements must not have a context, see VerifyHeadWithContext.
+func VerifyHead(head *Statement, kid ID, fetch func(seq int) (*Statement, error)) error {
+	return VerifyHeadWithContext(head, kid, "", fetch)
+}
+
+// VerifyHeadWithContext is VerifyHead for a Sigchain with a context (see
+// NewSigchainWithContext).
+func VerifyHeadWithContext(head *Statement, kid ID, context string, fetch func(seq int) (*Statement, error)) error {
+	if head == nil {
+		return errors.Errorf("no head statement")
+	}
+	st := head
+	for {
+		if st.KID != kid {
+			return errors.Errorf("invalid statement kid")
+		}
+		if st.Context != context {
+			return errors.Errorf("invalid statement context")
+		}
+		if err := st.Verify(); err != nil {
+			return err
+		}
+		if st.Seq < 1 {
+			return errors.Errorf("invalid statement sequence %d", st.Seq)
+		}
+		if st.Seq == 1 {
+			if st.Prev != nil {
+				return errors.Errorf("invalid statement previous, expected empty, got %s", st.Prev)
+			}
+			return nil
+		}
+		if len(st.Prev) == 0 {
+			return errors.Errorf("invalid statement previous empty")
+		}
+		prev, err := fetch(st.Seq - 1)
+		if err != nil {
+			return err
+		}
+		if prev == nil {
+			return errors.Errorf("statement %d not found", st.Seq-1)
+		}
+		if prev.Seq != st.Seq-1 {
+			return errors.Errorf("invalid statement sequence expected %d, got %d", st.Seq-1, prev.Seq)
+		}
+		prevHash, err := SigchainHash(prev)
+		if err != nil {
+			return err
+		}
+		if !bytes.Equal(st.Prev, prevHash[:]) {
+			return errors.Errorf("invalid statement previous, expected %x, got %x", prevHash, st.Prev)
+		}
+		st = prev
+	}
+}
+
 // FindLast search from the last statement to the first, returning after
 // If type is specified, we will search for that statement type.
 // If we found a statement and it was revoked, we return nil.
@@ -303,14 +937,18 @@ func (s *Sigchain) FindLast(typ string) *Statement {
 
 // FindAll returns statements of type.
 func (s *Sigchain) FindAll(typ string) []*Statement {
-	sts := make([]*Statement, 0, 10)
-	for _, st := range s.statements {
-		if typ != "" && st.Type == typ {
-			if s.IsRevoked(st.Seq) {
-				continue
-			}
-			sts = append(sts, st)
+	return s.FindAllByType(typ)
+}
+
+// FindAllByType returns statements of type, excluding revoked statements.
+// This uses an index of statements by type, so doesn't scan the Sigchain.
+func (s *Sigchain) FindAllByType(typ string) []*Statement {
+	sts := make([]*Statement, 0, len(s.types[typ]))
+	for _, st := range s.types[typ] {
+		if s.IsRevoked(st.Seq) {
+			continue
 		}
+		sts = append(sts, st)
 	}
 	return sts
 }
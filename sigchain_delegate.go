@@ -0,0 +1,118 @@
+package keys
+
+import (
+	"encoding/json"
+	"time"
+
+	"github.com/pkg/errors"
+)
+
+// Delegation authorizes a delegate key to add statements to a Sigchain.
+// It is the data of a "delegate" statement, see NewDelegateStatement.
+type Delegation struct {
+	// KID of the delegate.
+	KID ID `json:"kid"`
+	// Capabilities are the statement types the delegate can add.
+	Capabilities []string `json:"caps"`
+}
+
+// Allows returns true if the delegate can add a statement of type typ.
+func (d Delegation) Allows(typ string) bool {
+	for _, c := range d.Capabilities {
+		if c == typ {
+			return true
+		}
+	}
+	return false
+}
+
+// NewDelegateStatement creates a statement authorizing a delegate key to add
+// statements with types in capabilities (see NewSigchainDelegatedStatement).
+// The delegation is active until the statement is revoked.
+func NewDelegateStatement(sc *Sigchain, delegate ID, capabilities []string, sk *EdX25519Key, ts time.Time) (*Statement, error) {
+	if delegate == sc.KID() {
+		return nil, errors.Errorf("invalid delegate")
+	}
+	if _, err := StatementPublicKeyFromID(delegate); err != nil {
+		return nil, err
+	}
+	if len(capabilities) == 0 {
+		return nil, errors.Errorf("no capabilities")
+	}
+	b, err := json.Marshal(&Delegation{KID: delegate, Capabilities: capabilities})
+	if err != nil {
+		return nil, err
+	}
+	return NewSigchainStatement(sc, b, sk, "delegate", ts)
+}
+
+// NewSigchainDelegatedStatement creates a statement signed by a delegate key
+// (see NewDelegateStatement) to be added to the Sigchain.
+func NewSigchainDelegatedStatement(sc *Sigchain, b []byte, dk *EdX25519Key, typ string, ts time.Time) (*Statement, error) {
+	if sc == nil {
+		return nil, errors.Errorf("no sigchain specified")
+	}
+	st, err := unsignedSigchainStatement(sc.Last(), b, sc.KID(), typ, ts, sc.context)
+	if err != nil {
+		return nil, err
+	}
+	st.Signer = dk.ID()
+	if err := st.Sign(dk); err != nil {
+		return nil, err
+	}
+	return st, nil
+}
+
+// Delegation returns the active (not revoked) delegation for a delegate key,
+// or nil if there isn't one.
+func (s *Sigchain) Delegation(kid ID) *Delegation {
+	for _, st := range s.FindAllByType("delegate") {
+		d, err := delegationFromStatement(st)
+		if err != nil {
+			continue
+		}
+		if d.KID == kid {
+			return d
+		}
+	}
+	return nil
+}
+
+func delegationFromStatement(st *Statement) (*Delegation, error) {
+	var d Delegation
+	if err := json.Unmarshal(st.Data, &d); err != nil {
+		return nil, errors.Errorf("invalid delegation")
+	}
+	if d.KID == "" || d.KID == st.KID {
+		return nil, errors.Errorf("invalid delegation")
+	}
+	return &d, nil
+}
+
+// VerifyDelegated verifies a statement signed by a delegate (Signer) with the
+// delegation for it.
+// A statement can't prove its own delegation, so d must be from the verified
+// Sigchain for the statement KID (see Sigchain.Delegation), which checks it
+// wasn't revoked.
+func VerifyDelegated(st *Statement, d *Delegation) error {
+	if st.Signer == "" {
+		return errors.Errorf("no statement signer")
+	}
+	if d == nil || d.KID != st.Signer {
+		return errors.Errorf("invalid statement signer %s", st.Signer)
+	}
+	if !d.Allows(st.Type) {
+		return errors.Errorf("delegate %s not allowed to add %q", st.Signer, st.Type)
+	}
+	return st.verifySignature()
+}
+
+// verifyDelegate checks a "delegate" statement.
+func (s *Sigchain) verifyDelegate(st *Statement) error {
+	if st.Type == "delegate" {
+		if _, err := delegationFromStatement(st); err != nil {
+			return err
+		}
+	}
+	return nil
+}
@@ -0,0 +1,135 @@
+package keys_test
+
+import (
+	"encoding/json"
+	"testing"
+
+	"github.com/keys-pub/keys"
+	"github.com/keys-pub/keys/tsutil"
+	"github.com/stretchr/testify/require"
+)
+
+func TestSigchainDelegate(t *testing.T) {
+	clock := tsutil.NewTestClock()
+	alice := keys.NewEdX25519KeyFromSeed(testSeed(0x01))
+	delegate := keys.NewEdX25519KeyFromSeed(testSeed(0x02))
+	sc := keys.NewSigchain(alice.ID())
+
+	// Not a delegate (yet)
+	st, err := keys.NewSigchainDelegatedStatement(sc, []byte("hi"), delegate, "note", clock.Now())
+	require.NoError(t, err)
+	err = sc.Add(st)
+	require.EqualError(t, err, "invalid statement signer "+delegate.ID().String())
+
+	dst, err := keys.NewDelegateStatement(sc, delegate.ID(), []string{"note"}, alice, clock.Now())
+	require.NoError(t, err)
+	err = sc.Add(dst)
+	require.NoError(t, err)
+	require.Equal(t, &keys.Delegation{KID: delegate.ID(), Capabilities: []string{"note"}}, sc.Delegation(delegate.ID()))
+
+	st, err = keys.NewSigchainDelegatedStatement(sc, []byte("hi"), delegate, "note", clock.Now())
+	require.NoError(t, err)
+	require.Equal(t, alice.ID(), st.KID)
+	require.Equal(t, delegate.ID(), st.Signer)
+	err = sc.Add(st)
+	require.NoError(t, err)
+
+	b, err := st.MarshalJSON()
+	require.NoError(t, err)
+	require.Contains(t, string(b), `"signer":"`+delegate.ID().String()+`"`)
+	var out keys.Statement
+	err = json.Unmarshal(b, &out)
+	require.NoError(t, err)
+	require.Equal(t, delegate.ID(), out.Signer)
+	err = keys.VerifyDelegated(&out, sc.Delegation(delegate.ID()))
+	require.NoError(t, err)
+
+	// Capability
+	st, err = keys.NewSigchainDelegatedStatement(sc, []byte("hi"), delegate, "user", clock.Now())
+	require.NoError(t, err)
+	err = sc.Add(st)
+	require.EqualError(t, err, "delegate "+delegate.ID().String()+` not allowed to add "user"`)
+
+	// Changing the signer invalidates the signature
+	st, err = keys.NewSigchainDelegatedStatement(sc, []byte("hi"), delegate, "note", clock.Now())
+	require.NoError(t, err)
+	st.Signer = alice.ID()
+	err = sc.Add(st)
+	require.EqualError(t, err, "invalid statement signer "+alice.ID().String())
+	err = keys.VerifyDelegated(st, &keys.Delegation{KID: alice.ID(), Capabilities: []string{"note"}})
+	require.EqualError(t, err, "verify failed")
+
+	// Revoke delegation
+	_, err = sc.Revoke(dst.Seq, alice)
+	require.NoError(t, err)
+	require.Nil(t, sc.Delegation(delegate.ID()))
+	st, err = keys.NewSigchainDelegatedStatement(sc, []byte("hi"), delegate, "note", clock.Now())
+	require.NoError(t, err)
+	err = sc.Add(st)
+	require.EqualError(t, err, "invalid statement signer "+delegate.ID().String())
+
+	// Verifier
+	v, err := keys.NewSigchainVerifier(alice.ID())
+	require.NoError(t, err)
+	for _, st := range sc.Statements() {
+		err = v.Feed(st)
+		require.NoError(t, err)
+	}
+	err = v.Feed(st)
+	require.EqualError(t, err, "invalid statement signer "+delegate.ID().String())
+
+	_, err = keys.NewDelegateStatement(sc, alice.ID(), []string{"note"}, alice, clock.Now())
+	require.EqualError(t, err, "invalid delegate")
+}
+
+func TestSigchainDelegateForged(t *testing.T) {
+	clock := tsutil.NewTestClock()
+	alice := keys.NewEdX25519KeyFromSeed(testSeed(0x01))
+	mallory := keys.NewEdX25519KeyFromSeed(testSeed(0x03))
+	sc := keys.NewSigchain(alice.ID())
+	st, err := keys.NewSigchainStatement(sc, []byte("hi"), alice, "note", clock.Now())
+	require.NoError(t, err)
+	err = sc.Add(st)
+	require.NoError(t, err)
+
+	// Statement for alice, signed by mallory, who alice never delegated to
+	forged, err := keys.NewSigchainDelegatedStatement(sc, []byte("hi"), mallory, "note", clock.Now())
+	require.NoError(t, err)
+	unverified := "unverified statement signer " + mallory.ID().String()
+
+	err = forged.Verify()
+	require.EqualError(t, err, unverified)
+	err = forged.VerifySpecific(forged.BytesToSign())
+	require.EqualError(t, err, unverified)
+	_, err = forged.Bytes()
+	require.EqualError(t, err, unverified)
+
+	b, err := forged.MarshalJSON()
+	require.NoError(t, err)
+	_, err = keys.StatementFromBytes(b)
+	require.EqualError(t, err, unverified)
+	cb, err := forged.MarshalCBOR()
+	require.NoError(t, err)
+	_, err = keys.StatementFromCBOR(cb)
+	require.EqualError(t, err, unverified)
+
+	err = keys.VerifyDelegated(forged, nil)
+	require.EqualError(t, err, "invalid statement signer "+mallory.ID().String())
+	err = keys.VerifyDelegated(forged, &keys.Delegation{KID: mallory.ID(), Capabilities: []string{"other"}})
+	require.EqualError(t, err, "delegate "+mallory.ID().String()+` not allowed to add "note"`)
+
+	err = keys.VerifyHead(forged, alice.ID(), func(seq int) (*keys.Statement, error) {
+		return sc.Statements()[seq-1], nil
+	})
+	require.EqualError(t, err, unverified)
+
+	v, err := keys.NewSigchainVerifier(alice.ID())
+	require.NoError(t, err)
+	err = v.Feed(st)
+	require.NoError(t, err)
+	err = v.Feed(forged)
+	require.EqualError(t, err, "invalid statement signer "+mallory.ID().String())
+
+	err = sc.Add(forged)
+	require.EqualError(t, err, "invalid statement signer "+mallory.ID().String())
+}
@@ -0,0 +1,45 @@
+package keys
+
+import (
+	"github.com/pkg/errors"
+)
+
+// ErrSigchainConflict if sigchains have different statements at the same seq.
+var ErrSigchainConflict = errors.New("sigchain conflict")
+
+// DiffSigchains returns the statements in remote that aren't in local (by seq).
+// If local and remote have different statements at the same seq, returns
+// ErrSigchainConflict.
+func DiffSigchains(local *Sigchain, remote *Sigchain) ([]*Statement, error) {
+	if local.KID() != remote.KID() {
+		return nil, errors.Errorf("sigchain kid mismatch")
+	}
+	return local.diff(remote.Statements())
+}
+
+// Merge adds statements that extend the Sigchain.
+// Statements already in the Sigchain (with the same seq) are skipped if they
+// are equal, otherwise returns ErrSigchainConflict.
+// If any statement fails to verify, none of the statements are added.
+func (s *Sigchain) Merge(sts []*Statement) error {
+	add, err := s.diff(sts)
+	if err != nil {
+		return err
+	}
+	return s.AddAll(add)
+}
+
+func (s *Sigchain) diff(sts []*Statement) ([]*Statement, error) {
+	out := []*Statement{}
+	for _, st := range sts {
+		existing := s.StatementBySeq(st.Seq)
+		if existing == nil {
+			out = append(out, st)
+			continue
+		}
+		if !StatementsEqual(existing, st) {
+			return nil, errors.Wrapf(ErrSigchainConflict, "seq %d", st.Seq)
+		}
+	}
+	return out, nil
+}
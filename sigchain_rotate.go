@@ -0,0 +1,46 @@
+package keys
+
+import (
+	"encoding/json"
+	"time"
+
+	"github.com/pkg/errors"
+)
+
+// Rotation is the data of a "rotate" statement, see NewKeyRotationStatement.
+type Rotation struct {
+	// KID of the new key.
+	KID ID `json:"kid"`
+}
+
+// NewKeyRotationStatement creates a statement saying the sigchain identity is
+// also (now) at a new key.
+// The rotation is active until the statement is revoked, see RotatedTo.
+func NewKeyRotationStatement(sc *Sigchain, newKey *EdX25519PublicKey, sk *EdX25519Key, ts time.Time) (*Statement, error) {
+	if newKey == nil || newKey.ID() == sc.KID() {
+		return nil, errors.Errorf("invalid rotation key")
+	}
+	b, err := json.Marshal(&Rotation{KID: newKey.ID()})
+	if err != nil {
+		return nil, err
+	}
+	return NewSigchainStatement(sc, b, sk, "rotate", ts)
+}
+
+// RotatedTo returns the key from the most recent (not revoked) rotate
+// statement, or nil if there isn't one.
+func (s *Sigchain) RotatedTo() *EdX25519PublicKey {
+	sts := s.FindAllByType("rotate")
+	for i := len(sts) - 1; i >= 0; i-- {
+		var r Rotation
+		if err := json.Unmarshal(sts[i].Data, &r); err != nil {
+			continue
+		}
+		spk, err := NewEdX25519PublicKeyFromID(r.KID)
+		if err != nil {
+			continue
+		}
+		return spk
+	}
+	return nil
+}
@@ -0,0 +1,43 @@
+package keys_test
+
+import (
+	"testing"
+
+	"github.com/keys-pub/keys"
+	"github.com/keys-pub/keys/tsutil"
+	"github.com/stretchr/testify/require"
+)
+
+func TestSigchainRotate(t *testing.T) {
+	clock := tsutil.NewTestClock()
+	alice := keys.NewEdX25519KeyFromSeed(testSeed(0x01))
+	alice2 := keys.NewEdX25519KeyFromSeed(testSeed(0x02))
+	alice3 := keys.NewEdX25519KeyFromSeed(testSeed(0x03))
+	sc := keys.NewSigchain(alice.ID())
+	require.Nil(t, sc.RotatedTo())
+
+	_, err := keys.NewKeyRotationStatement(sc, alice.PublicKey(), alice, clock.Now())
+	require.EqualError(t, err, "invalid rotation key")
+
+	st, err := keys.NewKeyRotationStatement(sc, alice2.PublicKey(), alice, clock.Now())
+	require.NoError(t, err)
+	require.Equal(t, "rotate", st.Type)
+	err = sc.Add(st)
+	require.NoError(t, err)
+	require.Equal(t, alice2.ID(), sc.RotatedTo().ID())
+
+	st3, err := keys.NewKeyRotationStatement(sc, alice3.PublicKey(), alice, clock.Now())
+	require.NoError(t, err)
+	err = sc.Add(st3)
+	require.NoError(t, err)
+	require.Equal(t, alice3.ID(), sc.RotatedTo().ID())
+
+	// Revoke most recent, falls back to previous
+	_, err = sc.Revoke(st3.Seq, alice)
+	require.NoError(t, err)
+	require.Equal(t, alice2.ID(), sc.RotatedTo().ID())
+
+	_, err = sc.Revoke(st.Seq, alice)
+	require.NoError(t, err)
+	require.Nil(t, sc.RotatedTo())
+}
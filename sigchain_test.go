@@ -2,14 +2,19 @@ package keys_test
 
 import (
 	"bytes"
+	"crypto/sha256"
 	"encoding/hex"
 	"encoding/json"
 	"io/ioutil"
 	"log"
+	"math"
 	"testing"
+	"time"
 
 	"github.com/keys-pub/keys"
+	"github.com/keys-pub/keys/encoding"
 	"github.com/keys-pub/keys/tsutil"
+	"github.com/pkg/errors"
 	"github.com/stretchr/testify/require"
 )
 
@@ -142,6 +147,77 @@ func TestSigchain(t *testing.T) {
 	require.Equal(t, string(testdata(t, "testdata/sc2.spew")), spew.String())
 }
 
+func TestSigchainSpewJSON(t *testing.T) {
+	clock := tsutil.NewTestClock()
+	alice := keys.NewEdX25519KeyFromSeed(testSeed(0x01))
+	sc := keys.NewSigchain(alice.ID())
+	st, err := keys.NewSigchainStatement(sc, []byte("hi"), alice, "test", clock.Now())
+	require.NoError(t, err)
+	err = sc.Add(st)
+	require.NoError(t, err)
+
+	b, err := sc.SpewJSON()
+	require.NoError(t, err)
+	expected := `[{"key":"/sigchain/kex132yw8ht5p8cetl2jmvknewjawt9xwzdlrk2pyxlnwjyqrdq0dawqqph077/1","statement":` + string(st.SigBytes()) + `}]`
+	require.Equal(t, expected, string(b))
+
+	b, err = sc.SpewJSON(keys.SpewWithSig(keys.SpewSigElide))
+	require.NoError(t, err)
+	expected = `[{"key":"/sigchain/kex132yw8ht5p8cetl2jmvknewjawt9xwzdlrk2pyxlnwjyqrdq0dawqqph077/1","statement":` + string(st.BytesToSign()) + `}]`
+	require.Equal(t, expected, string(b))
+
+	b, err = sc.SpewJSON(keys.SpewWithSig(keys.SpewSigTruncate))
+	require.NoError(t, err)
+	var entries []struct {
+		Statement struct {
+			Sig []byte `json:".sig"`
+		} `json:"statement"`
+	}
+	err = json.Unmarshal(b, &entries)
+	require.NoError(t, err)
+	require.Equal(t, 1, len(entries))
+	require.Equal(t, st.Sig[:8], entries[0].Statement.Sig)
+}
+
+func TestSigchainRevokeRange(t *testing.T) {
+	clock := tsutil.NewTestClock()
+	alice := keys.NewEdX25519KeyFromSeed(testSeed(0x01))
+	sc := keys.NewSigchain(alice.ID())
+	for i := 0; i < 5; i++ {
+		st, err := keys.NewSigchainStatement(sc, bytes.Repeat([]byte{0x01}, 16), alice, "test", clock.Now())
+		require.NoError(t, err)
+		err = sc.Add(st)
+		require.NoError(t, err)
+	}
+	_, err := sc.Revoke(2, alice)
+	require.NoError(t, err)
+
+	revokes, err := sc.RevokeRange(1, 6, alice)
+	require.NoError(t, err)
+	require.Equal(t, 4, len(revokes))
+	require.Equal(t, []int{1, 3, 4, 5}, []int{revokes[0].Revoke, revokes[1].Revoke, revokes[2].Revoke, revokes[3].Revoke})
+	require.Equal(t, 10, sc.Length())
+	for seq := 1; seq <= 5; seq++ {
+		require.True(t, sc.IsRevoked(seq))
+	}
+	require.Equal(t, revokes[1], sc.RevocationOf(3))
+	require.Equal(t, 6, sc.RevocationOf(2).Seq)
+	require.Nil(t, sc.RevocationOf(6))
+
+	// Nothing left to revoke
+	revokes, err = sc.RevokeRange(1, 5, alice)
+	require.NoError(t, err)
+	require.Equal(t, 0, len(revokes))
+
+	_, err = sc.RevokeRange(0, 5, alice)
+	require.EqualError(t, err, "invalid revoke range 0-5")
+	_, err = sc.RevokeRange(3, 11, alice)
+	require.EqualError(t, err, "invalid revoke range 3-11")
+	bob := keys.NewEdX25519KeyFromSeed(testSeed(0x02))
+	_, err = sc.RevokeRange(1, 2, bob)
+	require.EqualError(t, err, "invalid sigchain public key")
+}
+
 func TestSigchainJSON(t *testing.T) {
 	clock := tsutil.NewTestClock()
 	sk := keys.NewEdX25519KeyFromSeed(testSeed(0x01))
@@ -238,3 +314,637 @@ func ExampleNewSigchain() {
 	// Output:
 	//
 }
+
+func TestSigchainMaxLength(t *testing.T) {
+	clock := tsutil.NewTestClock()
+	alice := keys.NewEdX25519KeyFromSeed(testSeed(0x01))
+
+	sc := keys.NewSigchain(alice.ID())
+	sc.SetMaxLength(3)
+	require.Equal(t, 3, sc.MaxLength())
+	require.Equal(t, 3, sc.Limits().MaxStatements)
+
+	for i := 0; i < 2; i++ {
+		st, err := keys.NewSigchainStatement(sc, bytes.Repeat([]byte{0x01}, 16), alice, "test", clock.Now())
+		require.NoError(t, err)
+		err = sc.Add(st)
+		require.NoError(t, err)
+	}
+	// Revokes count towards the limit
+	_, err := sc.Revoke(1, alice)
+	require.NoError(t, err)
+	require.Equal(t, 3, sc.Length())
+
+	st, err := keys.NewSigchainStatement(sc, bytes.Repeat([]byte{0x01}, 16), alice, "test", clock.Now())
+	require.NoError(t, err)
+	err = sc.Add(st)
+	require.True(t, errors.Is(err, keys.ErrChainTooLong))
+	require.EqualError(t, err, "sigchain limit max-statements (3) exceeded")
+	require.Equal(t, 3, sc.Length())
+
+	_, err = sc.Revoke(2, alice)
+	require.True(t, errors.Is(err, keys.ErrChainTooLong))
+	require.Equal(t, keys.ErrSigchainLimit{Limit: "max-statements", Max: 3}, err)
+
+	// Other limits aren't ErrChainTooLong
+	require.False(t, errors.Is(keys.ErrSigchainLimit{Limit: "max-data-bytes", Max: 3}, keys.ErrChainTooLong))
+
+	// Unlimited
+	sc.SetMaxLength(0)
+	err = sc.Add(st)
+	require.NoError(t, err)
+	require.Equal(t, 4, sc.Length())
+}
+
+func TestSigchainContext(t *testing.T) {
+	clock := tsutil.NewTestClock()
+	alice := keys.NewEdX25519KeyFromSeed(testSeed(0x01))
+
+	sc := keys.NewSigchainWithContext(alice.ID(), "app1")
+	require.Equal(t, "app1", sc.Context())
+	st, err := keys.NewSigchainStatement(sc, []byte("hi"), alice, "test", clock.Now())
+	require.NoError(t, err)
+	require.Equal(t, "app1", st.Context)
+	err = sc.Add(st)
+	require.NoError(t, err)
+	_, err = sc.Revoke(1, alice)
+	require.NoError(t, err)
+
+	b, err := st.Bytes()
+	require.NoError(t, err)
+	require.Contains(t, string(b), `"ctx":"app1"`)
+	out, err := keys.StatementFromBytes(b)
+	require.NoError(t, err)
+	require.Equal(t, "app1", out.Context)
+
+	// Statement from a different context isn't accepted
+	sc2 := keys.NewSigchainWithContext(alice.ID(), "app2")
+	err = sc2.Add(st)
+	require.EqualError(t, err, "invalid statement context")
+	sc3 := keys.NewSigchain(alice.ID())
+	err = sc3.Add(st)
+	require.EqualError(t, err, "invalid statement context")
+
+	// Changing the context invalidates the signature
+	st2 := *st
+	st2.Context = "app2"
+	require.EqualError(t, st2.Verify(), "verify failed")
+
+	// Without context, statement bytes are unchanged
+	st3, err := keys.NewSigchainStatement(sc3, []byte("hi"), alice, "test", clock.Now())
+	require.NoError(t, err)
+	b, err = st3.Bytes()
+	require.NoError(t, err)
+	require.NotContains(t, string(b), `"ctx"`)
+
+	// Verifier and VerifyHead require the same context
+	v, err := keys.NewSigchainVerifier(alice.ID())
+	require.NoError(t, err)
+	err = v.Feed(st)
+	require.EqualError(t, err, "invalid statement context")
+	v, err = keys.NewSigchainVerifierWithContext(alice.ID(), "app2")
+	require.NoError(t, err)
+	err = v.Feed(st)
+	require.EqualError(t, err, "invalid statement context")
+	v, err = keys.NewSigchainVerifierWithContext(alice.ID(), "app1")
+	require.NoError(t, err)
+	for _, st := range sc.Statements() {
+		err = v.Feed(st)
+		require.NoError(t, err)
+	}
+	fetch := func(seq int) (*keys.Statement, error) {
+		return sc.Statements()[seq-1], nil
+	}
+	err = keys.VerifyHead(sc.Last(), alice.ID(), fetch)
+	require.EqualError(t, err, "invalid statement context")
+	err = keys.VerifyHeadWithContext(sc.Last(), alice.ID(), "app2", fetch)
+	require.EqualError(t, err, "invalid statement context")
+	err = keys.VerifyHeadWithContext(sc.Last(), alice.ID(), "app1", fetch)
+	require.NoError(t, err)
+
+	// Import requires the same context
+	exported, err := sc.Export()
+	require.NoError(t, err)
+	imported, err := keys.ImportSigchainWithContext(exported, "app1")
+	require.NoError(t, err)
+	require.Equal(t, "app1", imported.Context())
+	require.Equal(t, 2, imported.Length())
+	_, err = keys.ImportSigchainWithContext(exported, "app2")
+	require.EqualError(t, err, "invalid statement context")
+	_, err = keys.ImportSigchain(exported)
+	require.EqualError(t, err, "invalid statement context")
+
+	// Invalid context
+	_, err = keys.NewSigchainStatement(keys.NewSigchainWithContext(alice.ID(), `"`), []byte("hi"), alice, "test", clock.Now())
+	require.EqualError(t, err, "invalid statement context")
+}
+
+func TestSigchainNewStatement(t *testing.T) {
+	alice := keys.NewEdX25519KeyFromSeed(testSeed(0x01))
+	sc := keys.NewSigchain(alice.ID())
+	sc.SetClock(tsutil.NewTestClock())
+
+	st, err := sc.NewStatement([]byte("hi"), alice, "test")
+	require.NoError(t, err)
+	require.Equal(t, int64(1234567890001), tsutil.Millis(st.Timestamp))
+	err = sc.Add(st)
+	require.NoError(t, err)
+
+	st, err = sc.NewStatement([]byte("hi2"), alice, "test")
+	require.NoError(t, err)
+	require.Equal(t, int64(1234567890002), tsutil.Millis(st.Timestamp))
+	require.Equal(t, 2, st.Seq)
+
+	bob := keys.NewEdX25519KeyFromSeed(testSeed(0x02))
+	_, err = sc.NewStatement([]byte("hi"), bob, "test")
+	require.EqualError(t, err, "invalid sigchain public key")
+}
+
+func TestSigchainVerifyTimestamps(t *testing.T) {
+	alice := keys.NewEdX25519KeyFromSeed(testSeed(0x01))
+	ts := tsutil.ParseMillis(1234567890000)
+
+	sc := keys.NewSigchain(alice.ID())
+	st1, err := keys.NewSigchainStatement(sc, bytes.Repeat([]byte{0x01}, 16), alice, "test", ts)
+	require.NoError(t, err)
+	err = sc.Add(st1)
+	require.NoError(t, err)
+	// Equal timestamp
+	st2, err := keys.NewSigchainStatement(sc, bytes.Repeat([]byte{0x01}, 16), alice, "test", ts)
+	require.NoError(t, err)
+	err = sc.Add(st2)
+	require.NoError(t, err)
+	st3, err := keys.NewSigchainStatement(sc, bytes.Repeat([]byte{0x01}, 16), alice, "test", ts.Add(-time.Millisecond))
+	require.NoError(t, err)
+
+	// Disabled by default
+	sc2 := keys.NewSigchain(alice.ID())
+	err = sc2.AddAll([]*keys.Statement{st1, st2, st3})
+	require.NoError(t, err)
+
+	sc.SetVerifyTimestamps(true)
+	err = sc.Add(st3)
+	require.EqualError(t, err, "seq 3: timestamp 1234567889999 is earlier than previous 1234567890000")
+	require.Equal(t, 2, sc.Length())
+
+	sc3 := keys.NewSigchain(alice.ID())
+	sc3.SetVerifyTimestamps(true)
+	err = sc3.AddAll([]*keys.Statement{st1, st2, st3})
+	require.EqualError(t, err, "seq 3: timestamp 1234567889999 is earlier than previous 1234567890000")
+	require.Equal(t, 0, sc3.Length())
+}
+
+func TestSigchainLimits(t *testing.T) {
+	clock := tsutil.NewTestClock()
+	alice := keys.NewEdX25519KeyFromSeed(testSeed(0x01))
+
+	sc := keys.NewSigchain(alice.ID())
+	require.Equal(t, keys.DefaultSigchainLimits, sc.Limits())
+	sc.SetLimits(keys.SigchainLimits{MaxDataBytes: 10})
+
+	st, err := keys.NewSigchainStatement(sc, bytes.Repeat([]byte{0x01}, 11), alice, "test", clock.Now())
+	require.NoError(t, err)
+	err = sc.Add(st)
+	require.EqualError(t, err, "sigchain limit max-data-bytes (10) exceeded")
+	require.Equal(t, keys.ErrSigchainLimit{Limit: "max-data-bytes", Max: 10}, err)
+
+	// Total is the serialized size of the statements (not just data)
+	total := 0
+	for i := 0; i < 2; i++ {
+		st, err := keys.NewSigchainStatement(sc, bytes.Repeat([]byte{0x01}, 10), alice, "test", clock.Now())
+		require.NoError(t, err)
+		err = sc.Add(st)
+		require.NoError(t, err)
+		b, err := st.Bytes()
+		require.NoError(t, err)
+		total += len(b)
+	}
+	sc.SetLimits(keys.SigchainLimits{MaxDataBytes: 10, MaxTotalBytes: total + 10})
+	st, err = keys.NewSigchainStatement(sc, bytes.Repeat([]byte{0x01}, 6), alice, "test", clock.Now())
+	require.NoError(t, err)
+	err = sc.Add(st)
+	require.Equal(t, keys.ErrSigchainLimit{Limit: "max-total-bytes", Max: total + 10}, err)
+	require.Equal(t, 2, sc.Length())
+
+	// AddAll
+	sc2 := keys.NewSigchain(alice.ID())
+	sc2.SetLimits(keys.SigchainLimits{MaxTotalBytes: total})
+	err = sc2.AddAll(sc.Statements())
+	require.NoError(t, err)
+	err = sc2.AddAll([]*keys.Statement{st})
+	require.Equal(t, keys.ErrSigchainLimit{Limit: "max-total-bytes", Max: total}, err)
+	sc3 := keys.NewSigchain(alice.ID())
+	sc3.SetLimits(keys.SigchainLimits{MaxTotalBytes: total - 1})
+	err = sc3.AddAll(sc.Statements())
+	require.Equal(t, keys.ErrSigchainLimit{Limit: "max-total-bytes", Max: total - 1}, err)
+	require.Equal(t, 0, sc3.Length())
+
+	// Default max statements
+	require.Equal(t, 100000, keys.DefaultSigchainLimits.MaxStatements)
+
+	// Import uses the default limits
+	big := keys.NewSigchain(alice.ID())
+	big.SetLimits(keys.SigchainLimits{})
+	st, err = keys.NewSigchainStatement(big, bytes.Repeat([]byte{0x01}, keys.DefaultSigchainLimits.MaxDataBytes+1), alice, "test", clock.Now())
+	require.NoError(t, err)
+	err = big.Add(st)
+	require.NoError(t, err)
+	b, err := big.Export()
+	require.NoError(t, err)
+	_, err = keys.ImportSigchain(b)
+	require.EqualError(t, err, "sigchain limit max-data-bytes (1048576) exceeded")
+}
+
+func TestSigchainOnAdd(t *testing.T) {
+	clock := tsutil.NewTestClock()
+	alice := keys.NewEdX25519KeyFromSeed(testSeed(0x01))
+
+	sc := keys.NewSigchain(alice.ID())
+
+	added := []*keys.Statement{}
+	sc.OnAdd(func(st *keys.Statement) {
+		// Statement is in the chain when called
+		require.Equal(t, st, sc.Last())
+		added = append(added, st)
+	})
+	revoked := []int{}
+	sc.OnRevoke(func(seq int, revoke *keys.Statement) {
+		require.Equal(t, revoke, sc.Last())
+		revoked = append(revoked, seq)
+	})
+
+	st, err := keys.NewSigchainStatement(sc, bytes.Repeat([]byte{0x01}, 16), alice, "test", clock.Now())
+	require.NoError(t, err)
+	err = sc.Add(st)
+	require.NoError(t, err)
+	require.Equal(t, []*keys.Statement{st}, added)
+	require.Equal(t, []int{}, revoked)
+
+	// Failed add doesn't call back
+	err = sc.Add(st)
+	require.Error(t, err)
+	require.Equal(t, 1, len(added))
+
+	rst, err := sc.Revoke(1, alice)
+	require.NoError(t, err)
+	require.Equal(t, []*keys.Statement{st, rst}, added)
+	require.Equal(t, []int{1}, revoked)
+}
+
+func TestSigchainPage(t *testing.T) {
+	clock := tsutil.NewTestClock()
+	alice := keys.NewEdX25519KeyFromSeed(testSeed(0x01))
+	sc := keys.NewSigchain(alice.ID())
+
+	page, next, err := sc.Page(0, 3)
+	require.NoError(t, err)
+	require.Equal(t, 0, len(page))
+	require.Equal(t, 0, next)
+
+	for i := 0; i < 10; i++ {
+		st, err := keys.NewSigchainStatement(sc, bytes.Repeat([]byte{0x01}, 16), alice, "test", clock.Now())
+		require.NoError(t, err)
+		err = sc.Add(st)
+		require.NoError(t, err)
+	}
+
+	out := []*keys.Statement{}
+	seq := 0
+	pages := 0
+	for {
+		page, next, err := sc.Page(seq, 3)
+		require.NoError(t, err)
+		out = append(out, page...)
+		pages++
+		if next == 0 {
+			break
+		}
+		seq = next
+	}
+	require.Equal(t, 4, pages)
+	require.Equal(t, sc.Statements(), out)
+
+	page, next, err = sc.Page(5, 5)
+	require.NoError(t, err)
+	require.Equal(t, 5, len(page))
+	require.Equal(t, 6, page[0].Seq)
+	require.Equal(t, 0, next)
+
+	page, next, err = sc.Page(1, math.MaxInt64)
+	require.NoError(t, err)
+	require.Equal(t, 9, len(page))
+	require.Equal(t, 0, next)
+
+	// Appending to a page doesn't change the sigchain
+	page, _, err = sc.Page(0, 1)
+	require.NoError(t, err)
+	_ = append(page, &keys.Statement{Seq: 99})
+	require.Equal(t, 2, sc.Statements()[1].Seq)
+
+	_, _, err = sc.Page(0, 0)
+	require.EqualError(t, err, "invalid limit 0")
+	_, _, err = sc.Page(-1, 1)
+	require.EqualError(t, err, "invalid seq -1")
+}
+
+func TestVerifyHead(t *testing.T) {
+	clock := tsutil.NewTestClock()
+	alice := keys.NewEdX25519KeyFromSeed(testSeed(0x01))
+	sc := keys.NewSigchain(alice.ID())
+
+	for i := 0; i < 5; i++ {
+		st, err := keys.NewSigchainStatement(sc, bytes.Repeat([]byte{0x01}, 16), alice, "test", clock.Now())
+		require.NoError(t, err)
+		err = sc.Add(st)
+		require.NoError(t, err)
+	}
+
+	fetched := []int{}
+	fetch := func(seq int) (*keys.Statement, error) {
+		fetched = append(fetched, seq)
+		if seq < 1 || seq > sc.Length() {
+			return nil, nil
+		}
+		return sc.Statements()[seq-1], nil
+	}
+
+	err := keys.VerifyHead(sc.Last(), alice.ID(), fetch)
+	require.NoError(t, err)
+	require.Equal(t, []int{4, 3, 2, 1}, fetched)
+
+	err = keys.VerifyHead(sc.Statements()[0], alice.ID(), fetch)
+	require.NoError(t, err)
+
+	bob := keys.NewEdX25519KeyFromSeed(testSeed(0x02))
+	err = keys.VerifyHead(sc.Last(), bob.ID(), fetch)
+	require.EqualError(t, err, "invalid statement kid")
+
+	// Head signed by bob as a (forged) delegate
+	forged, err := keys.NewSigchainDelegatedStatement(sc, []byte("forged"), bob, "test", clock.Now())
+	require.NoError(t, err)
+	err = keys.VerifyHead(forged, alice.ID(), fetch)
+	require.EqualError(t, err, "unverified statement signer "+bob.ID().String())
+
+	// Fetch returns a statement that isn't the previous
+	err = keys.VerifyHead(sc.Last(), alice.ID(), func(seq int) (*keys.Statement, error) {
+		if seq == 2 {
+			return sc.Statements()[2], nil
+		}
+		return fetch(seq)
+	})
+	require.EqualError(t, err, "invalid statement sequence expected 2, got 3")
+
+	// Fetch returns a different chain
+	sc2 := keys.NewSigchain(alice.ID())
+	for i := 0; i < 5; i++ {
+		st, err := keys.NewSigchainStatement(sc2, bytes.Repeat([]byte{0x02}, 16), alice, "test", clock.Now())
+		require.NoError(t, err)
+		err = sc2.Add(st)
+		require.NoError(t, err)
+	}
+	err = keys.VerifyHead(sc.Last(), alice.ID(), func(seq int) (*keys.Statement, error) {
+		return sc2.Statements()[seq-1], nil
+	})
+	require.Error(t, err)
+	require.Contains(t, err.Error(), "invalid statement previous, expected")
+
+	// Missing
+	err = keys.VerifyHead(sc.Last(), alice.ID(), func(seq int) (*keys.Statement, error) {
+		return nil, nil
+	})
+	require.EqualError(t, err, "statement 4 not found")
+}
+
+func TestSigchainExportImport(t *testing.T) {
+	clock := tsutil.NewTestClock()
+	alice := keys.NewEdX25519KeyFromSeed(testSeed(0x01))
+	sc := keys.NewSigchain(alice.ID())
+	for i := 0; i < 3; i++ {
+		st, err := keys.NewSigchainStatement(sc, bytes.Repeat([]byte{0x01}, 16), alice, "test", clock.Now())
+		require.NoError(t, err)
+		err = sc.Add(st)
+		require.NoError(t, err)
+	}
+	_, err := sc.Revoke(2, alice)
+	require.NoError(t, err)
+
+	b, err := sc.Export()
+	require.NoError(t, err)
+
+	out, err := keys.ImportSigchain(b)
+	require.NoError(t, err)
+	require.Equal(t, alice.ID(), out.KID())
+	require.Equal(t, 4, out.Length())
+	require.True(t, out.IsRevoked(2))
+	require.Equal(t, sc.Spew().String(), out.Spew().String())
+
+	// Out of order
+	var raw []json.RawMessage
+	err = json.Unmarshal(b, &raw)
+	require.NoError(t, err)
+	raw[1], raw[2] = raw[2], raw[1]
+	b2, err := json.Marshal(raw)
+	require.NoError(t, err)
+	_, err = keys.ImportSigchain(b2)
+	require.EqualError(t, err, "invalid statement sequence expected 2, got 3")
+
+	_, err = keys.ImportSigchain([]byte("[]"))
+	require.EqualError(t, err, "no statements")
+}
+
+func TestSigchainStatementsRange(t *testing.T) {
+	clock := tsutil.NewTestClock()
+	alice := keys.NewEdX25519KeyFromSeed(testSeed(0x01))
+	sc := keys.NewSigchain(alice.ID())
+
+	require.Nil(t, sc.StatementBySeq(1))
+	require.Equal(t, 0, len(sc.StatementsRange(1, 10)))
+
+	for i := 0; i < 4; i++ {
+		st, err := keys.NewSigchainStatement(sc, bytes.Repeat([]byte{0x01}, 16), alice, "test", clock.Now())
+		require.NoError(t, err)
+		err = sc.Add(st)
+		require.NoError(t, err)
+	}
+	revoke, err := sc.Revoke(2, alice)
+	require.NoError(t, err)
+
+	require.Equal(t, 3, sc.StatementBySeq(3).Seq)
+	require.Equal(t, revoke, sc.StatementBySeq(5))
+	require.Nil(t, sc.StatementBySeq(0))
+	require.Nil(t, sc.StatementBySeq(6))
+
+	seqs := func(sts []*keys.Statement) []int {
+		out := []int{}
+		for _, st := range sts {
+			out = append(out, st.Seq)
+		}
+		return out
+	}
+	require.Equal(t, []int{2, 3, 4}, seqs(sc.StatementsRange(2, 4)))
+	require.Equal(t, []int{4, 5}, seqs(sc.StatementsRange(4, 60)))
+	require.Equal(t, []int{1, 2}, seqs(sc.StatementsRange(0, 2)))
+	require.Equal(t, []int{3}, seqs(sc.StatementsRange(3, 3)))
+	require.Equal(t, []int{}, seqs(sc.StatementsRange(6, 10)))
+	require.Equal(t, []int{}, seqs(sc.StatementsRange(4, 3)))
+
+	// Appending to a range doesn't change the sigchain
+	r := sc.StatementsRange(1, 1)
+	_ = append(r, &keys.Statement{Seq: 99})
+	require.Equal(t, 2, sc.Statements()[1].Seq)
+}
+
+func TestSigchainFindAllByType(t *testing.T) {
+	clock := tsutil.NewTestClock()
+	alice := keys.NewEdX25519KeyFromSeed(testSeed(0x01))
+	sc := keys.NewSigchain(alice.ID())
+
+	for _, typ := range []string{"a", "b", "a", "", "a"} {
+		st, err := keys.NewSigchainStatement(sc, bytes.Repeat([]byte{0x01}, 16), alice, typ, clock.Now())
+		require.NoError(t, err)
+		err = sc.Add(st)
+		require.NoError(t, err)
+	}
+	_, err := sc.Revoke(3, alice)
+	require.NoError(t, err)
+
+	seqs := func(sts []*keys.Statement) []int {
+		out := []int{}
+		for _, st := range sts {
+			out = append(out, st.Seq)
+		}
+		return out
+	}
+	require.Equal(t, []int{1, 5}, seqs(sc.FindAllByType("a")))
+	require.Equal(t, []int{2}, seqs(sc.FindAllByType("b")))
+	require.Equal(t, []int{6}, seqs(sc.FindAllByType("revoke")))
+	require.Equal(t, []int{}, seqs(sc.FindAllByType("c")))
+	require.Equal(t, []int{}, seqs(sc.FindAllByType("")))
+	require.Equal(t, seqs(sc.FindAll("a")), seqs(sc.FindAllByType("a")))
+}
+
+func TestNewSigchainStatements(t *testing.T) {
+	clock := tsutil.NewTestClock()
+	alice := keys.NewEdX25519KeyFromSeed(testSeed(0x01))
+	sc := keys.NewSigchain(alice.ID())
+	st, err := keys.NewSigchainStatement(sc, bytes.Repeat([]byte{0x01}, 16), alice, "test", clock.Now())
+	require.NoError(t, err)
+	err = sc.Add(st)
+	require.NoError(t, err)
+
+	entries := []keys.StatementInput{}
+	for i := 0; i < 100; i++ {
+		entries = append(entries, keys.StatementInput{Data: []byte{byte(i)}, Type: "test"})
+	}
+	sts, err := keys.NewSigchainStatements(sc, entries, alice, clock.Now)
+	require.NoError(t, err)
+	require.Equal(t, 100, len(sts))
+	require.Equal(t, 2, sts[0].Seq)
+	require.Equal(t, 1, sc.Length())
+
+	added := 0
+	sc.OnAdd(func(st *keys.Statement) { added++ })
+
+	// Invalid statement in the batch leaves the Sigchain unchanged
+	bad := make([]*keys.Statement, len(sts))
+	copy(bad, sts)
+	bad[50] = sts[51]
+	err = sc.AddAll(bad)
+	require.EqualError(t, err, "invalid statement sequence expected 52, got 53")
+	require.Equal(t, 1, sc.Length())
+	require.Equal(t, 1, len(sc.FindAll("test")))
+	require.Equal(t, 0, added)
+
+	err = sc.AddAll(sts)
+	require.NoError(t, err)
+	require.Equal(t, 101, sc.Length())
+	require.Equal(t, 101, len(sc.FindAll("test")))
+	require.Equal(t, 100, added)
+
+	bob := keys.NewEdX25519KeyFromSeed(testSeed(0x02))
+	_, err = keys.NewSigchainStatements(sc, entries, bob, clock.Now)
+	require.EqualError(t, err, "invalid sigchain public key")
+}
+
+func TestSigchainMerge(t *testing.T) {
+	clock := tsutil.NewTestClock()
+	alice := keys.NewEdX25519KeyFromSeed(testSeed(0x01))
+
+	remote := keys.NewSigchain(alice.ID())
+	for i := 0; i < 5; i++ {
+		st, err := keys.NewSigchainStatement(remote, bytes.Repeat([]byte{0x01}, 16), alice, "test", clock.Now())
+		require.NoError(t, err)
+		err = remote.Add(st)
+		require.NoError(t, err)
+	}
+
+	local := keys.NewSigchain(alice.ID())
+	err := local.AddAll(remote.Statements()[:2])
+	require.NoError(t, err)
+
+	diff, err := keys.DiffSigchains(local, remote)
+	require.NoError(t, err)
+	require.Equal(t, 3, len(diff))
+	require.Equal(t, 3, diff[0].Seq)
+
+	err = local.Merge(remote.Statements())
+	require.NoError(t, err)
+	require.Equal(t, remote.Spew().String(), local.Spew().String())
+
+	diff, err = keys.DiffSigchains(local, remote)
+	require.NoError(t, err)
+	require.Equal(t, 0, len(diff))
+
+	// Conflict
+	forked := keys.NewSigchain(alice.ID())
+	err = forked.AddAll(remote.Statements()[:3])
+	require.NoError(t, err)
+	st, err := keys.NewSigchainStatement(forked, bytes.Repeat([]byte{0x02}, 16), alice, "test", clock.Now())
+	require.NoError(t, err)
+	err = forked.Add(st)
+	require.NoError(t, err)
+
+	_, err = keys.DiffSigchains(forked, remote)
+	require.EqualError(t, err, "seq 4: sigchain conflict")
+	require.Equal(t, keys.ErrSigchainConflict, errors.Cause(err))
+	err = forked.Merge(remote.Statements())
+	require.Equal(t, keys.ErrSigchainConflict, errors.Cause(err))
+	require.Equal(t, 4, forked.Length())
+
+	bob := keys.NewEdX25519KeyFromSeed(testSeed(0x02))
+	_, err = keys.DiffSigchains(keys.NewSigchain(bob.ID()), remote)
+	require.EqualError(t, err, "sigchain kid mismatch")
+}
+
+func TestSigchainPrev(t *testing.T) {
+	clock := tsutil.NewTestClock()
+	alice := keys.NewEdX25519KeyFromSeed(testSeed(0x01))
+	sc := keys.NewSigchain(alice.ID())
+	st, err := keys.NewSigchainStatement(sc, []byte("hi"), alice, "test", clock.Now())
+	require.NoError(t, err)
+	err = sc.Add(st)
+	require.NoError(t, err)
+	st2, err := keys.NewSigchainStatement(sc, []byte("hi2"), alice, "test", clock.Now())
+	require.NoError(t, err)
+
+	expected := `{".sig":"` + encoding.MustEncode(st.Sig, encoding.Base64) + `","data":"aGk=","kid":"kex132yw8ht5p8cetl2jmvknewjawt9xwzdlrk2pyxlnwjyqrdq0dawqqph077","seq":1,"ts":1234567890001,"type":"test"}`
+	require.Equal(t, expected, string(st.SigBytes()))
+	h := sha256.Sum256([]byte(expected))
+	require.Equal(t, h[:], st.PrevHash())
+	require.Equal(t, st.PrevHash(), st2.Prev)
+	require.Equal(t, "sha256", keys.SigchainHashAlgorithm)
+
+	require.NoError(t, keys.VerifyPrev(st, nil))
+	require.NoError(t, keys.VerifyPrev(st2, st))
+	err = keys.VerifyPrev(st2, nil)
+	require.EqualError(t, err, "invalid statement previous, expected empty, got "+string(st2.Prev))
+	err = keys.VerifyPrev(st, st2)
+	require.EqualError(t, err, "invalid statement previous empty")
+
+	// Countersignature doesn't change the hash
+	tsa := keys.NewEdX25519KeyFromSeed(testSeed(0x02))
+	err = keys.CountersignStatement(st, tsa, clock.Now())
+	require.NoError(t, err)
+	require.Equal(t, h[:], st.PrevHash())
+	require.NoError(t, keys.VerifyPrev(st2, st))
+}
@@ -0,0 +1,138 @@
+package keys
+
+import (
+	"bytes"
+
+	"github.com/pkg/errors"
+)
+
+// SigchainVerifier verifies Sigchain statements incrementally, as they are
+// received in order, without keeping the statements in memory.
+// It checks the same things as Sigchain.Add (kid, context, signature, seq,
+// prev, revokes and delegates).
+type SigchainVerifier struct {
+	spk      StatementPublicKey
+	context  string
+	lastSeq  int
+	prevHash []byte
+	// revokeSeqs are the seqs of revoke statements, so revoking a revoke can be
+	// rejected.
+	revokeSeqs map[int]bool
+	// delegations are the active delegations by seq.
+	delegations map[int]*Delegation
+}
+
+// NewSigchainVerifier creates a SigchainVerifier for a Sigchain with kid
+// (without a context).
+func NewSigchainVerifier(kid ID) (*SigchainVerifier, error) {
+	return NewSigchainVerifierWithContext(kid, "")
+}
+
+// NewSigchainVerifierWithContext creates a SigchainVerifier for a Sigchain
+// with kid and context (see NewSigchainWithContext).
+func NewSigchainVerifierWithContext(kid ID, context string) (*SigchainVerifier, error) {
+	spk, err := StatementPublicKeyFromID(kid)
+	if err != nil {
+		return nil, err
+	}
+	return &SigchainVerifier{
+		spk:         spk,
+		context:     context,
+		revokeSeqs:  map[int]bool{},
+		delegations: map[int]*Delegation{},
+	}, nil
+}
+
+// PublicKey for the Sigchain.
+func (v *SigchainVerifier) PublicKey() StatementPublicKey {
+	return v.spk
+}
+
+// LastSeq returns the seq of the last verified statement (or 0 if none).
+func (v *SigchainVerifier) LastSeq() int {
+	return v.lastSeq
+}
+
+// Feed verifies the next statement.
+// If the statement is invalid, an error is returned and the verifier state is
+// unchanged.
+func (v *SigchainVerifier) Feed(st *Statement) error {
+	if st.KID != v.spk.ID() {
+		return errors.Errorf("invalid statement kid")
+	}
+	if st.Context != v.context {
+		return errors.Errorf("invalid statement context")
+	}
+	if len(st.Data) == 0 && st.Type != "revoke" {
+		return errors.Errorf("no data")
+	}
+	if st.Signer != "" {
+		if err := VerifyDelegated(st, v.delegation(st.Signer)); err != nil {
+			return err
+		}
+	} else if err := st.Verify(); err != nil {
+		return err
+	}
+	var delegation *Delegation
+	if st.Type == "delegate" {
+		d, err := delegationFromStatement(st)
+		if err != nil {
+			return err
+		}
+		delegation = d
+	}
+	if st.Seq != v.lastSeq+1 {
+		return errors.Errorf("invalid statement sequence expected %d, got %d", v.lastSeq+1, st.Seq)
+	}
+	if v.prevHash == nil {
+		if st.Prev != nil {
+			return errors.Errorf("invalid statement previous, expected empty, got %s", st.Prev)
+		}
+	} else {
+		if len(st.Prev) == 0 {
+			return errors.Errorf("invalid statement previous empty")
+		}
+		if !bytes.Equal(st.Prev, v.prevHash) {
+			return errors.Errorf("invalid statement previous, expected %x, got %x", v.prevHash, st.Prev)
+		}
+	}
+
+	if st.Revoke != 0 {
+		if st.Revoke == st.Seq {
+			return errors.Errorf("revoke self is unsupported")
+		}
+		if st.Revoke > st.Seq {
+			return errors.Errorf("revoke index is greater than current index")
+		}
+		if st.Revoke < 1 {
+			return errors.Errorf("revoke is less than 1")
+		}
+		if v.revokeSeqs[st.Revoke] {
+			return errors.Errorf("revoking a revoke is unsupported")
+		}
+	}
+
+	h, err := SigchainHash(st)
+	if err != nil {
+		return err
+	}
+	if st.Revoke != 0 {
+		v.revokeSeqs[st.Seq] = true
+		delete(v.delegations, st.Revoke)
+	}
+	if delegation != nil {
+		v.delegations[st.Seq] = delegation
+	}
+	v.lastSeq = st.Seq
+	v.prevHash = h[:]
+	return nil
+}
+
+func (v *SigchainVerifier) delegation(kid ID) *Delegation {
+	for _, d := range v.delegations {
+		if d.KID == kid {
+			return d
+		}
+	}
+	return nil
+}
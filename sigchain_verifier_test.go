@@ -0,0 +1,61 @@
+package keys_test
+
+import (
+	"bytes"
+	"testing"
+
+	"github.com/keys-pub/keys"
+	"github.com/keys-pub/keys/tsutil"
+	"github.com/stretchr/testify/require"
+)
+
+func TestSigchainVerifier(t *testing.T) {
+	clock := tsutil.NewTestClock()
+	alice := keys.NewEdX25519KeyFromSeed(testSeed(0x01))
+	sc := keys.NewSigchain(alice.ID())
+	for i := 0; i < 3; i++ {
+		st, err := keys.NewSigchainStatement(sc, bytes.Repeat([]byte{0x01}, 16), alice, "test", clock.Now())
+		require.NoError(t, err)
+		err = sc.Add(st)
+		require.NoError(t, err)
+	}
+	_, err := sc.Revoke(2, alice)
+	require.NoError(t, err)
+	sts := sc.Statements()
+
+	v, err := keys.NewSigchainVerifier(alice.ID())
+	require.NoError(t, err)
+	require.Equal(t, alice.ID(), v.PublicKey().ID())
+	require.Equal(t, 0, v.LastSeq())
+
+	// Out of order
+	err = v.Feed(sts[1])
+	require.EqualError(t, err, "invalid statement sequence expected 1, got 2")
+	require.Equal(t, 0, v.LastSeq())
+
+	for _, st := range sts {
+		err = v.Feed(st)
+		require.NoError(t, err)
+	}
+	require.Equal(t, 4, v.LastSeq())
+
+	// Replay
+	err = v.Feed(sts[3])
+	require.EqualError(t, err, "invalid statement sequence expected 5, got 4")
+
+	// Bad signature
+	st, err := keys.NewSigchainStatement(sc, bytes.Repeat([]byte{0x02}, 16), alice, "test", clock.Now())
+	require.NoError(t, err)
+	st.Data = bytes.Repeat([]byte{0x03}, 16)
+	err = v.Feed(st)
+	require.EqualError(t, err, "verify failed")
+	require.Equal(t, 4, v.LastSeq())
+
+	// Different kid
+	bob := keys.NewEdX25519KeyFromSeed(testSeed(0x02))
+	scBob := keys.NewSigchain(bob.ID())
+	stBob, err := keys.NewSigchainStatement(scBob, bytes.Repeat([]byte{0x01}, 16), bob, "test", clock.Now())
+	require.NoError(t, err)
+	err = v.Feed(stBob)
+	require.EqualError(t, err, "invalid statement kid")
+}
@@ -4,6 +4,7 @@ import (
 	"context"
 	"encoding/json"
 	"strings"
+	"sync"
 
 	"github.com/keys-pub/keys/dstore"
 	"github.com/keys-pub/keys/tsutil"
@@ -56,20 +57,32 @@ func (s *Sigchains) KIDs() ([]ID, error) {
 	return ids.IDs(), nil
 }
 
+// Sigchains returns all the sigchains.
+// Sigchains are loaded with Sigchain, so they must not have a context.
+// For a large number of sigchains, use KIDs and load each Sigchain as needed.
+func (s *Sigchains) Sigchains() ([]*Sigchain, error) {
+	kids, err := s.KIDs()
+	if err != nil {
+		return nil, err
+	}
+	scs := make([]*Sigchain, 0, len(kids))
+	for _, kid := range kids {
+		sc, err := s.Sigchain(kid)
+		if err != nil {
+			return nil, err
+		}
+		scs = append(scs, sc)
+	}
+	return scs, nil
+}
+
 // Save sigchain.
 func (s *Sigchains) Save(sc *Sigchain) error {
 	if len(sc.Statements()) == 0 {
 		return errors.Errorf("failed to save sigchain: no statements")
 	}
 	for _, st := range sc.Statements() {
-		b, err := st.Bytes()
-		if err != nil {
-			return err
-		}
-		if st.Seq <= 0 {
-			return errors.Errorf("statement sequence missing")
-		}
-		if err := s.ds.Set(context.TODO(), dstore.Path("sigchain", StatementID(st.KID, st.Seq)), dstore.Data(b)); err != nil {
+		if err := s.saveStatement(st); err != nil {
 			return err
 		}
 	}
@@ -79,6 +92,33 @@ func (s *Sigchains) Save(sc *Sigchain) error {
 	return nil
 }
 
+// SaveStatement saves a statement, for example one just added to a Sigchain,
+// without saving the rest of the sigchain.
+// The statement isn't verified against the stored sigchain, use Sigchain.Add
+// first.
+func (s *Sigchains) SaveStatement(st *Statement) error {
+	if err := s.saveStatement(st); err != nil {
+		return err
+	}
+	if st.Seq == 1 {
+		if err := s.Index(st.KID); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func (s *Sigchains) saveStatement(st *Statement) error {
+	b, err := st.MarshalJSON()
+	if err != nil {
+		return err
+	}
+	if st.Seq <= 0 {
+		return errors.Errorf("statement sequence missing")
+	}
+	return s.ds.Set(context.TODO(), dstore.Path("sigchain", StatementID(st.KID, st.Seq)), dstore.Data(b))
+}
+
 func statementFromDocument(doc *dstore.Document) (*Statement, error) {
 	var st Statement
 	if err := json.Unmarshal(doc.Data(), &st); err != nil {
@@ -88,14 +128,22 @@ func statementFromDocument(doc *dstore.Document) (*Statement, error) {
 }
 
 // Sigchain returns sigchain for key.
+// Statements must not have a context, see SigchainWithContext.
 func (s *Sigchains) Sigchain(kid ID) (*Sigchain, error) {
+	return s.SigchainWithContext(kid, "")
+}
+
+// SigchainWithContext returns sigchain for key with a context (see
+// NewSigchainWithContext).
+// Returns an error if a statement has a different context.
+func (s *Sigchains) SigchainWithContext(kid ID, sigchainContext string) (*Sigchain, error) {
 	// logger.Debugf("Loading sigchain %s", kid)
 	iter, err := s.ds.DocumentIterator(context.TODO(), "sigchain", dstore.Prefix(kid.String()))
 	if err != nil {
 		return nil, err
 	}
 
-	sc := NewSigchain(kid)
+	sc := NewSigchainWithContext(kid, sigchainContext)
 	for {
 		doc, err := iter.Next()
 		if err != nil {
@@ -117,6 +165,72 @@ func (s *Sigchains) Sigchain(kid ID) (*Sigchain, error) {
 	return sc, nil
 }
 
+// VerifyResult is the result for a sigchain from VerifyAll.
+type VerifyResult struct {
+	KID ID
+	// Length of the sigchain, if verified.
+	Length int
+	// Err if sigchain failed to load or verify.
+	Err error
+}
+
+// verifyAllWorkers is how many sigchains VerifyAll loads at the same time.
+const verifyAllWorkers = 4
+
+// VerifyAll loads and verifies all the sigchains, sending a result for each
+// to the returned channel. The channel is closed when done or if the context
+// is canceled.
+// Sigchains are loaded with Sigchain, so a sigchain with a context fails.
+func (s *Sigchains) VerifyAll(ctx context.Context) (<-chan VerifyResult, error) {
+	kids, err := s.KIDs()
+	if err != nil {
+		return nil, err
+	}
+
+	kidc := make(chan ID)
+	out := make(chan VerifyResult)
+
+	go func() {
+		defer close(kidc)
+		for _, kid := range kids {
+			select {
+			case kidc <- kid:
+			case <-ctx.Done():
+				return
+			}
+		}
+	}()
+
+	var wg sync.WaitGroup
+	for i := 0; i < verifyAllWorkers; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for kid := range kidc {
+				res := VerifyResult{KID: kid}
+				sc, err := s.Sigchain(kid)
+				if err != nil {
+					res.Err = err
+				} else {
+					res.Length = sc.Length()
+				}
+				select {
+				case out <- res:
+				case <-ctx.Done():
+					return
+				}
+			}
+		}()
+	}
+
+	go func() {
+		wg.Wait()
+		close(out)
+	}()
+
+	return out, nil
+}
+
 func (s *Sigchains) sigchainPaths(kid ID) ([]string, error) {
 	iter, err := s.ds.DocumentIterator(context.TODO(), "sigchain", dstore.Prefix(kid.String()), dstore.NoData())
 	if err != nil {
@@ -138,6 +252,7 @@ func (s *Sigchains) sigchainPaths(kid ID) ([]string, error) {
 }
 
 // Delete sigchain.
+// Removes all the statements for kid and the reverse key lookup (see Index).
 func (s *Sigchains) Delete(kid ID) (bool, error) {
 	paths, err := s.sigchainPaths(kid)
 	if err != nil {
@@ -148,13 +263,17 @@ func (s *Sigchains) Delete(kid ID) (bool, error) {
 		return false, nil
 	}
 
-	for _, path := range paths {
-		if _, err := s.ds.Delete(context.TODO(), path); err != nil {
+	// Delete from the last statement, so if this fails part way, what's left
+	// is still a valid sigchain.
+	for i := len(paths) - 1; i >= 0; i-- {
+		if _, err := s.ds.Delete(context.TODO(), paths[i]); err != nil {
 			return false, err
 		}
 	}
 
-	// TODO: Delete reverse key lookup?
+	if err := s.unindex(kid); err != nil {
+		return false, err
+	}
 	return true, nil
 }
 
@@ -201,3 +320,19 @@ func (s *Sigchains) Index(key Key) error {
 	}
 	return nil
 }
+
+// unindex removes the reverse key lookup added by Index.
+func (s *Sigchains) unindex(kid ID) error {
+	if kid.Type() != EdX25519 {
+		return nil
+	}
+	pk, err := NewEdX25519PublicKeyFromID(kid)
+	if err != nil {
+		return err
+	}
+	rklPath := dstore.Path(indexRKL, pk.X25519PublicKey().ID())
+	if _, err := s.ds.Delete(context.TODO(), rklPath); err != nil {
+		return err
+	}
+	return nil
+}
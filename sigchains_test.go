@@ -1,6 +1,8 @@
 package keys_test
 
 import (
+	"bytes"
+	"context"
 	"testing"
 
 	"github.com/keys-pub/keys"
@@ -77,6 +79,13 @@ func TestSigchains(t *testing.T) {
 	}
 	require.Equal(t, expected, kids)
 
+	all, err := scs.Sigchains()
+	require.NoError(t, err)
+	require.Equal(t, 2, len(all))
+	require.Equal(t, alice.ID(), all[0].KID())
+	require.Equal(t, 2, all[0].Length())
+	require.Equal(t, bob.ID(), all[1].KID())
+
 	ok, err = scs.Delete(alice.ID())
 	require.NoError(t, err)
 	require.True(t, ok)
@@ -97,6 +106,56 @@ func TestSigchains(t *testing.T) {
 	require.False(t, ok)
 }
 
+func TestSigchainsContext(t *testing.T) {
+	clock := tsutil.NewTestClock()
+	scs := testSigchains(t, clock)
+	alice := keys.NewEdX25519KeyFromSeed(testSeed(0x01))
+
+	sca := keys.NewSigchainWithContext(alice.ID(), "app1")
+	for i := 0; i < 2; i++ {
+		st, err := keys.NewSigchainStatement(sca, []byte("alice"), alice, "", clock.Now())
+		require.NoError(t, err)
+		err = sca.Add(st)
+		require.NoError(t, err)
+	}
+	err := scs.Save(sca)
+	require.NoError(t, err)
+
+	sc, err := scs.SigchainWithContext(alice.ID(), "app1")
+	require.NoError(t, err)
+	require.Equal(t, "app1", sc.Context())
+	require.Equal(t, 2, sc.Length())
+
+	// Loading with a different context fails
+	_, err = scs.Sigchain(alice.ID())
+	require.EqualError(t, err, "invalid statement context")
+	_, err = scs.SigchainWithContext(alice.ID(), "app2")
+	require.EqualError(t, err, "invalid statement context")
+}
+
+func TestSigchainsSaveStatement(t *testing.T) {
+	clock := tsutil.NewTestClock()
+	scs := testSigchains(t, clock)
+	alice := keys.NewEdX25519KeyFromSeed(testSeed(0x01))
+
+	sc := keys.NewSigchain(alice.ID())
+	for i := 0; i < 3; i++ {
+		st, err := keys.NewSigchainStatement(sc, []byte("alice"), alice, "", clock.Now())
+		require.NoError(t, err)
+		err = sc.Add(st)
+		require.NoError(t, err)
+		err = scs.SaveStatement(st)
+		require.NoError(t, err)
+	}
+
+	out, err := scs.Sigchain(alice.ID())
+	require.NoError(t, err)
+	require.Equal(t, sc.Spew().String(), out.Spew().String())
+	kid, err := scs.Lookup(alice.X25519Key().ID())
+	require.NoError(t, err)
+	require.Equal(t, alice.ID(), kid)
+}
+
 func TestSigchainsSpew(t *testing.T) {
 	clock := tsutil.NewTestClock()
 	scs := testSigchains(t, clock)
@@ -151,4 +210,68 @@ func TestSigchainsLookup(t *testing.T) {
 	rk, err := scs.Lookup(keys.ID("kbx1rvd43h2sag2tvrdp0duse5p82nvhpjd6hpjwhv7q7vqklega8atshec5ws"))
 	require.NoError(t, err)
 	require.Equal(t, alice.ID(), rk)
+
+	// Delete removes lookup
+	ok, err := scs.Delete(alice.ID())
+	require.NoError(t, err)
+	require.True(t, ok)
+	rk, err = scs.Lookup(keys.ID("kbx1rvd43h2sag2tvrdp0duse5p82nvhpjd6hpjwhv7q7vqklega8atshec5ws"))
+	require.NoError(t, err)
+	require.Equal(t, keys.ID(""), rk)
+}
+
+func TestSigchainsVerifyAll(t *testing.T) {
+	clock := tsutil.NewTestClock()
+	mem := dstore.NewMem()
+	mem.SetClock(clock)
+	scs := keys.NewSigchains(mem)
+	scs.SetClock(clock)
+
+	for i := 1; i <= 4; i++ {
+		sk := keys.NewEdX25519KeyFromSeed(testSeed(byte(i)))
+		sc := keys.NewSigchain(sk.ID())
+		for j := 0; j < i; j++ {
+			st, err := keys.NewSigchainStatement(sc, []byte("test"), sk, "", clock.Now())
+			require.NoError(t, err)
+			err = sc.Add(st)
+			require.NoError(t, err)
+		}
+		err := scs.Save(sc)
+		require.NoError(t, err)
+	}
+
+	// Tamper with a statement
+	tampered := keys.NewEdX25519KeyFromSeed(testSeed(0x03))
+	path := dstore.Path("sigchain", keys.StatementID(tampered.ID(), 2))
+	doc, err := mem.Get(context.TODO(), path)
+	require.NoError(t, err)
+	b := bytes.Replace(doc.Data(), []byte(`"seq":2`), []byte(`"seq":5`), 1)
+	err = mem.Set(context.TODO(), path, dstore.Data(b))
+	require.NoError(t, err)
+
+	resc, err := scs.VerifyAll(context.TODO())
+	require.NoError(t, err)
+	results := map[keys.ID]keys.VerifyResult{}
+	for res := range resc {
+		results[res.KID] = res
+	}
+	require.Equal(t, 4, len(results))
+	for i := 1; i <= 4; i++ {
+		kid := keys.NewEdX25519KeyFromSeed(testSeed(byte(i))).ID()
+		res := results[kid]
+		if kid == tampered.ID() {
+			require.Error(t, res.Err)
+			continue
+		}
+		require.NoError(t, res.Err)
+		require.Equal(t, i, res.Length)
+	}
+
+	// Canceled
+	ctx, cancel := context.WithCancel(context.Background())
+	resc, err = scs.VerifyAll(ctx)
+	require.NoError(t, err)
+	cancel()
+	for range resc {
+	}
 }
@@ -0,0 +1,73 @@
+package keys
+
+import (
+	"crypto/hmac"
+	"crypto/sha512"
+	"encoding/binary"
+	"strconv"
+	"strings"
+
+	"github.com/pkg/errors"
+)
+
+// NewEdX25519KeyFromPath derives a EdX25519Key from a master seed and a
+// derivation path, using SLIP-0010 (Ed25519).
+// Only hardened derivation is supported for Ed25519, so each path index must
+// be hardened, for example "m/0'/1'/2'".
+func NewEdX25519KeyFromPath(seed []byte, path string) (*EdX25519Key, error) {
+	if len(seed) < 16 || len(seed) > 64 {
+		return nil, errors.Errorf("invalid seed length")
+	}
+	indexes, err := parseDerivationPath(path)
+	if err != nil {
+		return nil, err
+	}
+
+	key, chainCode := slip10Split(hmacSHA512([]byte("ed25519 seed"), seed))
+	for _, index := range indexes {
+		b := make([]byte, 0, 37)
+		b = append(b, 0x00)
+		b = append(b, key...)
+		b = append(b, uint32Bytes(index)...)
+		key, chainCode = slip10Split(hmacSHA512(chainCode, b))
+	}
+	return NewEdX25519KeyFromSeed(Bytes32(key)), nil
+}
+
+const hardenedOffset = uint32(0x80000000)
+
+// parseDerivationPath parses a path like "m/0'/1'" into (hardened) indexes.
+func parseDerivationPath(path string) ([]uint32, error) {
+	parts := strings.Split(path, "/")
+	if parts[0] != "m" {
+		return nil, errors.Errorf("invalid path %s", path)
+	}
+	indexes := make([]uint32, 0, len(parts)-1)
+	for _, part := range parts[1:] {
+		if !strings.HasSuffix(part, "'") {
+			return nil, errors.Errorf("invalid path %s: only hardened indexes are supported", path)
+		}
+		i, err := strconv.ParseUint(strings.TrimSuffix(part, "'"), 10, 31)
+		if err != nil {
+			return nil, errors.Errorf("invalid path %s", path)
+		}
+		indexes = append(indexes, uint32(i)+hardenedOffset)
+	}
+	return indexes, nil
+}
+
+func slip10Split(b []byte) ([]byte, []byte) {
+	return b[:32], b[32:]
+}
+
+func hmacSHA512(key []byte, b []byte) []byte {
+	h := hmac.New(sha512.New, key)
+	_, _ = h.Write(b)
+	return h.Sum(nil)
+}
+
+func uint32Bytes(i uint32) []byte {
+	b := make([]byte, 4)
+	binary.BigEndian.PutUint32(b, i)
+	return b
+}
@@ -0,0 +1,41 @@
+package keys_test
+
+import (
+	"testing"
+
+	"github.com/keys-pub/keys"
+	"github.com/keys-pub/keys/encoding"
+	"github.com/stretchr/testify/require"
+)
+
+func TestNewEdX25519KeyFromPath(t *testing.T) {
+	// Test vector 1 for ed25519 from SLIP-0010.
+	seed := encoding.MustDecode("000102030405060708090a0b0c0d0e0f", encoding.Hex)
+	vectors := []struct {
+		path string
+		seed string
+		pub  string
+	}{
+		{"m", "2b4be7f19ee27bbf30c667b642d5f4aa69fd169872f8fc3059c08ebae2eb19e7", "a4b2856bfec510abab89753fac1ac0e1112364e7d250545963f135f2a33188ed"},
+		{"m/0'", "68e0fe46dfb67e368c75379acec591dad19df3cde26e63b93a8e704f1dade7a3", "8c8a13df77a28f3445213a0f432fde644acaa215fc72dcdf300d5efaa85d350c"},
+		{"m/0'/1'", "b1d0bad404bf35da785a64ca1ac54b2617211d2777696fbffaf208f746ae84f2", "1932a5270f335bed617d5b935c80aedb1a35bd9fc1e31acafd5372c30f5c1187"},
+		{"m/0'/1'/2'", "92a5b23c0b8a99e37d07df3fb9966917f5d06e02ddbd909c7e184371463e9fc9", "ae98736566d30ed0e9d2f4486a64bc95740d89c7db33f52121f8ea8f76ff0fc1"},
+		{"m/0'/1'/2'/2'", "30d1dc7e5fc04c31219ab25a27ae00b50f6fd66622f6e9c913253d6511d1e662", "8abae2d66361c879b900d204ad2cc4984fa2aa344dd7ddc46007329ac76c429c"},
+		{"m/0'/1'/2'/2'/1000000000'", "8f94d394a8e8fd6b1bc2f3f49f5c47e385281d5c17e65324b0f62483e37e8793", "3c24da049451555d51a7014a37337aa4e12d41e485abccfa46b47dfb2af54b7a"},
+	}
+	for _, v := range vectors {
+		key, err := keys.NewEdX25519KeyFromPath(seed, v.path)
+		require.NoError(t, err)
+		require.Equal(t, v.seed, encoding.EncodeHex(key.Seed()[:]), v.path)
+		require.Equal(t, v.pub, encoding.EncodeHex(key.Public()), v.path)
+	}
+
+	_, err := keys.NewEdX25519KeyFromPath(seed, "m/0")
+	require.EqualError(t, err, "invalid path m/0: only hardened indexes are supported")
+	_, err = keys.NewEdX25519KeyFromPath(seed, "0'")
+	require.EqualError(t, err, "invalid path 0'")
+	_, err = keys.NewEdX25519KeyFromPath(seed, "m/2147483648'")
+	require.EqualError(t, err, "invalid path m/2147483648'")
+	_, err = keys.NewEdX25519KeyFromPath([]byte{0x01}, "m")
+	require.EqualError(t, err, "invalid seed length")
+}
@@ -22,3 +22,23 @@ func TestCryptoBoxSeal(t *testing.T) {
 	_, err = keys.CryptoBoxSealOpen(encrypted, charlie)
 	require.EqualError(t, err, "failed to box open")
 }
+
+func TestCryptoBoxSealRandomized(t *testing.T) {
+	alice := keys.GenerateX25519Key()
+	plaintext := []byte("my secret message")
+
+	encrypted1 := keys.CryptoBoxSeal(plaintext, alice.PublicKey())
+	encrypted2 := keys.CryptoBoxSeal(plaintext, alice.PublicKey())
+	// Different ephemeral keys
+	require.NotEqual(t, encrypted1[:32], encrypted2[:32])
+	require.NotEqual(t, encrypted1, encrypted2)
+
+	out1, err := keys.CryptoBoxSealOpen(encrypted1, alice)
+	require.NoError(t, err)
+	out2, err := keys.CryptoBoxSealOpen(encrypted2, alice)
+	require.NoError(t, err)
+	require.Equal(t, out1, out2)
+
+	_, err = keys.CryptoBoxSealOpen(encrypted1[:31], alice)
+	require.EqualError(t, err, "not enough data to box open")
+}
@@ -1,9 +1,15 @@
 package keys_test
 
 import (
+	"crypto/ed25519"
 	"encoding/hex"
 	"fmt"
+	"io/ioutil"
 	"log"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"strings"
 	"testing"
 
 	"github.com/keys-pub/keys"
@@ -227,3 +233,40 @@ func ExampleDecodeSSHKey() {
 	fmt.Printf("%s\n", pk.ID())
 	// Output: kex132yw8ht5p8cetl2jmvknewjawt9xwzdlrk2pyxlnwjyqrdq0dawqqph077
 }
+
+func TestSSHKeygenFingerprint(t *testing.T) {
+	path, err := exec.LookPath("ssh-keygen")
+	if err != nil {
+		t.Skip("ssh-keygen not found")
+	}
+	alice := keys.NewEdX25519KeyFromSeed(testSeed(0x01))
+	dir, err := ioutil.TempDir("", "KeysTest.")
+	require.NoError(t, err)
+	defer os.RemoveAll(dir)
+
+	sshPub, err := ssh.NewPublicKey(ed25519.PublicKey(alice.PublicKey().Bytes()))
+	require.NoError(t, err)
+	fingerprint := ssh.FingerprintSHA256(sshPub)
+
+	pub := append(alice.PublicKey().EncodeToSSHAuthorized(), []byte(" alice@keys.pub\n")...)
+	pubPath := filepath.Join(dir, "id_ed25519.pub")
+	err = ioutil.WriteFile(pubPath, pub, 0600)
+	require.NoError(t, err)
+	out, err := exec.Command(path, "-l", "-f", pubPath).CombinedOutput() // #nosec
+	require.NoError(t, err, string(out))
+	require.Equal(t, "256 "+fingerprint+" alice@keys.pub (ED25519)\n", string(out))
+
+	// Comment is ignored when parsing
+	key, err := keys.ParseSSHPublicKey(string(pub))
+	require.NoError(t, err)
+	require.Equal(t, alice.PublicKey(), key)
+
+	priv, err := alice.EncodeToSSH(nil)
+	require.NoError(t, err)
+	privPath := filepath.Join(dir, "id_ed25519")
+	err = ioutil.WriteFile(privPath, priv, 0600)
+	require.NoError(t, err)
+	out, err = exec.Command(path, "-l", "-f", privPath).CombinedOutput() // #nosec
+	require.NoError(t, err, string(out))
+	require.True(t, strings.HasPrefix(string(out), "256 "+fingerprint+" "), string(out))
+}
@@ -3,6 +3,7 @@ package keys
 import (
 	"bytes"
 	"fmt"
+	"strings"
 	"time"
 
 	"github.com/keys-pub/keys/encoding"
@@ -33,13 +34,46 @@ type Statement struct {
 	// Type (optional).
 	Type string
 
+	// Zip is the compression of Data, "gz" for gzip (optional).
+	// See NewSigchainStatementCompressed and UncompressedData.
+	Zip string
+
+	// Signer is a delegate key that signed the statement instead of KID
+	// (optional). See NewSigchainDelegatedStatement.
+	Signer ID
+
+	// CoKIDs are additional keys that must also sign the statement (optional).
+	// See NewSigchainMultiStatement.
+	CoKIDs []ID
+	// CoSigs are the signatures from CoKIDs, in the same order.
+	CoSigs [][]byte
+	// Version of the statement format, StatementVersionMultiSig if there are
+	// CoKIDs, otherwise 0 (not serialized).
+	Version int
+
+	// Context for domain separation (optional).
+	// It is part of the signed bytes, so a statement made for one context
+	// can't be used in a Sigchain with a different context.
+	// See NewSigchainWithContext.
+	Context string
+
 	// Timestamp (optional).
 	Timestamp time.Time
 
 	// Nonce (optional).
 	Nonce []byte
+
+	// TSASig is a countersignature from a timestamp authority (optional).
+	// See CountersignStatement.
+	TSASig []byte
+	// TSATimestamp is the time from the timestamp authority (optional).
+	TSATimestamp time.Time
 }
 
+// StatementVersionMultiSig is the Version of a statement with CoKIDs.
+// Statements without CoKIDs have no version, so their bytes are unchanged.
+const StatementVersionMultiSig = 2
+
 // StatementPublicKey describes a public key for a Statement.
 type StatementPublicKey interface {
 	ID() ID
@@ -59,14 +93,79 @@ func (s *Statement) Sign(signKey *EdX25519Key) error {
 	if s.Sig != nil {
 		return errors.Errorf("signature already set")
 	}
-	if s.KID != signKey.ID() {
+	if s.signer() != signKey.ID() {
 		return errors.Errorf("sign failed: key id mismatch")
 	}
+	if err := validateContext(s.Context); err != nil {
+		return err
+	}
+	if err := s.validateCoKIDs(); err != nil {
+		return err
+	}
 	b := s.BytesToSign()
 	s.Sig = signKey.SignDetached(b)
 	return nil
 }
 
+// Cosign adds a signature from one of the CoKIDs.
+func (s *Statement) Cosign(signKey *EdX25519Key) error {
+	if err := s.validateCoKIDs(); err != nil {
+		return err
+	}
+	for i, kid := range s.CoKIDs {
+		if kid != signKey.ID() {
+			continue
+		}
+		if len(s.CoSigs) != len(s.CoKIDs) {
+			s.CoSigs = make([][]byte, len(s.CoKIDs))
+		}
+		if s.CoSigs[i] != nil {
+			return errors.Errorf("cosignature already set")
+		}
+		s.CoSigs[i] = signKey.SignDetached(s.BytesToSign())
+		return nil
+	}
+	return errors.Errorf("cosign failed: key not a cosigner")
+}
+
+// signer is the key that signs the statement, KID unless there is a (delegate)
+// Signer.
+func (s *Statement) signer() ID {
+	if s.Signer != "" {
+		return s.Signer
+	}
+	return s.KID
+}
+
+// validateCoKIDs checks the CoKIDs don't repeat a signer and the Version
+// matches.
+func (s *Statement) validateCoKIDs() error {
+	version := 0
+	if len(s.CoKIDs) != 0 {
+		version = StatementVersionMultiSig
+	}
+	if s.Version != version {
+		return errors.Errorf("invalid statement version %d", s.Version)
+	}
+	seen := map[ID]bool{s.signer(): true}
+	for _, kid := range s.CoKIDs {
+		if seen[kid] {
+			return errors.Errorf("duplicate statement signer %s", kid)
+		}
+		seen[kid] = true
+	}
+	return nil
+}
+
+// validateContext checks the context can be serialized (ASCII, no quotes or
+// backslashes).
+func validateContext(ctx string) error {
+	if !encoding.IsASCII([]byte(ctx)) || strings.ContainsAny(ctx, "\"\\") {
+		return errors.Errorf("invalid statement context")
+	}
+	return nil
+}
+
 // StatementID returns and identifier for a Statement as kid-seq.
 // If seq is <= 0, returns kid.
 // The idenfifier looks like "kex1a4yj333g68pvd6hfqvufqkv4vy54jfe6t33ljd3kc9rpfty8xlgsfte2sn-000000000000001".
@@ -89,38 +188,122 @@ func (s *Statement) URL() string {
 
 type statementFormat struct {
 	Sig       []byte `json:".sig"`
+	CoKIDs    string `json:"co.kids"`
+	CoSigs    string `json:"co.sigs"`
+	Context   string `json:"ctx"`
 	Data      []byte `json:"data"`
 	KID       string `json:"kid"`
 	Nonce     []byte `json:"nonce"`
 	Prev      []byte `json:"prev"`
 	Revoke    int    `json:"revoke"`
 	Seq       int    `json:"seq"`
+	Signer    string `json:"signer"`
 	Timestamp int64  `json:"ts"`
+	TSASig    []byte `json:"tsa.sig"`
+	TSATime   int64  `json:"tsa.ts"`
 	Type      string `json:"type"`
+	Version   int    `json:"v"`
+	Zip       string `json:"zip"`
 }
 
 // Verify statement.
 // If you have the original bytes use VerifySpecific.
+// A statement signed by a delegate (Signer) can't be verified on its own,
+// since the delegation is in the Sigchain, so it fails; see VerifyDelegated
+// and Sigchain.Add.
 func (s *Statement) Verify() error {
-	spk, err := StatementPublicKeyFromID(s.KID)
+	if err := s.verifyNoSigner(); err != nil {
+		return err
+	}
+	return s.verifySignature()
+}
+
+// verifyNoSigner fails if the statement has a (delegate) Signer.
+func (s *Statement) verifyNoSigner() error {
+	if s.Signer != "" {
+		return errors.Errorf("unverified statement signer %s", s.Signer)
+	}
+	return nil
+}
+
+// verifySignature checks the signature (from the signer) and cosignatures.
+// It doesn't check a (delegate) Signer was authorized by KID.
+func (s *Statement) verifySignature() error {
+	spk, err := StatementPublicKeyFromID(s.signer())
 	if err != nil {
 		return err
 	}
 	if len(s.Sig) == 0 {
 		return errors.Errorf("missing signature")
 	}
+	if err := s.validateCoKIDs(); err != nil {
+		return err
+	}
 	b := s.BytesToSign()
 	if err := spk.VerifyDetached(s.Sig, b); err != nil {
 		return err
 	}
+	return s.verifyCosigs(b)
+}
+
+// verifyCosigs checks all CoKIDs signed.
+func (s *Statement) verifyCosigs(b []byte) error {
+	if len(s.CoKIDs) == 0 {
+		if len(s.CoSigs) != 0 {
+			return errors.Errorf("invalid cosignatures")
+		}
+		return nil
+	}
+	if len(s.CoSigs) != len(s.CoKIDs) {
+		return errors.Errorf("missing cosignature")
+	}
+	for i, kid := range s.CoKIDs {
+		if len(s.CoSigs[i]) == 0 {
+			return errors.Errorf("missing cosignature")
+		}
+		spk, err := StatementPublicKeyFromID(kid)
+		if err != nil {
+			return err
+		}
+		if err := spk.VerifyDetached(s.CoSigs[i], b); err != nil {
+			return err
+		}
+	}
 	return nil
 }
 
+// VerifyStatement verifies a statement is for the public key (kid) and is
+// signed by it, without a Sigchain (see Sigchain.Add to also check seq, prev,
+// revokes, etc).
+// A statement signed by a delegate (Signer) is rejected, since a single
+// statement can't prove its delegation (see VerifyDelegated).
+func VerifyStatement(st *Statement, spk *EdX25519PublicKey) error {
+	if st.KID != spk.ID() {
+		return errors.Errorf("invalid statement kid")
+	}
+	if err := st.verifyNoSigner(); err != nil {
+		return err
+	}
+	if len(st.Sig) == 0 {
+		return errors.Errorf("missing signature")
+	}
+	if err := st.validateCoKIDs(); err != nil {
+		return err
+	}
+	b := st.BytesToSign()
+	if err := spk.VerifyDetached(st.Sig, b); err != nil {
+		return err
+	}
+	return st.verifyCosigs(b)
+}
+
 // VerifySpecific and check that bytesToSign match the statement's
 // BytesToSign, to verify the original bytes match the specific
 // serialization.
+// If the statement has a countersignature, the bytesToSign should include it
+// (the original bytes without the signature).
 func (s *Statement) VerifySpecific(bytesToSign []byte) error {
-	serialized := s.BytesToSign()
+	serialized := statementBytes(s, nil, true)
 	// We want to verify the bytes we get before unmarshalling match the same
 	// bytes used to sign/verify after marshalling.
 	// https://latacora.micro.blog/2019/07/24/how-not-to.html
@@ -131,26 +314,62 @@ func (s *Statement) VerifySpecific(bytesToSign []byte) error {
 	return s.Verify()
 }
 
+// verifySpecific is VerifySpecific without checking a (delegate) Signer was
+// authorized.
+func (s *Statement) verifySpecific(bytesToSign []byte) error {
+	if !bytes.Equal(bytesToSign, statementBytes(s, nil, true)) {
+		return errors.Errorf("statement bytes failed to match specific serialization")
+	}
+	return s.verifySignature()
+}
+
+// StatementsEqual returns true if statements have the same canonical bytes
+// (including the signature).
+// Empty optional fields are the same as absent fields.
+// A countersignature (see CountersignStatement) isn't compared.
+func StatementsEqual(a *Statement, b *Statement) bool {
+	if a == nil || b == nil {
+		return a == b
+	}
+	return bytes.Equal(statementBytes(a, a.Sig, false), statementBytes(b, b.Sig, false))
+}
+
 // MarshalJSON marshals statement to JSON.
+// Unlike Bytes, a statement signed by a delegate (Signer) is marshaled without
+// checking the delegation, so a Sigchain with delegated statements can be
+// stored. The delegation is checked when it's added to a Sigchain.
 func (s *Statement) MarshalJSON() ([]byte, error) {
-	return s.Bytes()
+	if err := s.verifySignature(); err != nil {
+		return nil, err
+	}
+	return statementBytes(s, s.Sig, true), nil
 }
 
 // UnmarshalJSON unmarshals a statement from JSON.
+// Like MarshalJSON, a delegate (Signer) isn't checked, use Sigchain.Add (or
+// StatementFromBytes).
 func (s *Statement) UnmarshalJSON(b []byte) error {
 	st, err := unmarshalJSON(b)
 	if err != nil {
 		return err
 	}
 	s.Sig = st.Sig
+	s.CoKIDs = st.CoKIDs
+	s.CoSigs = st.CoSigs
+	s.Context = st.Context
 	s.Data = st.Data
 	s.KID = st.KID
 	s.Seq = st.Seq
+	s.Signer = st.Signer
 	s.Prev = st.Prev
 	s.Revoke = st.Revoke
 	s.Timestamp = st.Timestamp
 	s.Type = st.Type
+	s.Version = st.Version
+	s.Zip = st.Zip
 	s.Nonce = st.Nonce
+	s.TSASig = st.TSASig
+	s.TSATimestamp = st.TSATimestamp
 	return nil
 }
 
@@ -159,18 +378,42 @@ func (s *Statement) Bytes() ([]byte, error) {
 	if err := s.Verify(); err != nil {
 		return nil, err
 	}
-	return statementBytes(s, s.Sig), nil
+	return statementBytes(s, s.Sig, true), nil
 }
 
 // BytesToSign returns bytes to sign.
+// This is the serialized statement with an empty ".sig" value, and without a
+// countersignature. Data and other binary fields are base64 encoded and string
+// values are ASCII only, so the bytes don't depend on the JSON encoder.
 func (s *Statement) BytesToSign() []byte {
-	return statementBytes(s, nil)
+	return statementBytes(s, nil, false)
 }
 
-func statementBytes(st *Statement, sig []byte) []byte {
+// statementBytes returns the serialized statement with sig.
+// If tsa is true, the countersignature fields are included (if set).
+// Cosignatures are included if sig or tsa is set, so they are not part of the
+// bytes to sign.
+func statementBytes(st *Statement, sig []byte, tsa bool) []byte {
 	mes := []encoding.TextMarshaler{
 		json.String(".sig", encoding.MustEncode(sig, encoding.Base64)),
 	}
+	if len(st.CoKIDs) != 0 {
+		kids := make([]string, 0, len(st.CoKIDs))
+		for _, kid := range st.CoKIDs {
+			kids = append(kids, kid.String())
+		}
+		mes = append(mes, json.String("co.kids", strings.Join(kids, ",")))
+		if sig != nil || tsa {
+			sigs := make([]string, 0, len(st.CoSigs))
+			for _, cosig := range st.CoSigs {
+				sigs = append(sigs, encoding.MustEncode(cosig, encoding.Base64))
+			}
+			mes = append(mes, json.String("co.sigs", strings.Join(sigs, ",")))
+		}
+	}
+	if st.Context != "" {
+		mes = append(mes, json.String("ctx", st.Context))
+	}
 	if len(st.Data) != 0 {
 		mes = append(mes, json.String("data", encoding.MustEncode(st.Data, encoding.Base64)))
 	}
@@ -187,12 +430,27 @@ func statementBytes(st *Statement, sig []byte) []byte {
 	if st.Seq != 0 {
 		mes = append(mes, json.Int("seq", st.Seq))
 	}
+	if st.Signer != "" {
+		mes = append(mes, json.String("signer", st.Signer.String()))
+	}
 	if !st.Timestamp.IsZero() {
 		mes = append(mes, json.Int("ts", int(tsutil.Millis(st.Timestamp))))
 	}
+	if tsa && !st.TSATimestamp.IsZero() {
+		if len(st.TSASig) != 0 {
+			mes = append(mes, json.String("tsa.sig", encoding.MustEncode(st.TSASig, encoding.Base64)))
+		}
+		mes = append(mes, json.Int("tsa.ts", int(tsutil.Millis(st.TSATimestamp))))
+	}
 	if st.Type != "" {
 		mes = append(mes, json.String("type", st.Type))
 	}
+	if st.Version != 0 {
+		mes = append(mes, json.Int("v", st.Version))
+	}
+	if st.Zip != "" {
+		mes = append(mes, json.String("zip", st.Zip))
+	}
 
 	b, err := json.Marshal(mes...)
 	if err != nil {
@@ -201,7 +459,24 @@ func statementBytes(st *Statement, sig []byte) []byte {
 	return b
 }
 
+// StatementFromBytes returns a verified Statement from its serialized bytes.
+// The bytes must match the specific serialization of the Statement (see
+// VerifySpecific), so unknown fields, a different field order or whitespace
+// are rejected.
+// A statement signed by a delegate (Signer) is rejected (see Verify).
+func StatementFromBytes(b []byte) (*Statement, error) {
+	st, err := unmarshalJSON(b)
+	if err != nil {
+		return nil, err
+	}
+	if err := st.verifyNoSigner(); err != nil {
+		return nil, err
+	}
+	return st, nil
+}
+
 // unmarshalJSON returns a Statement from JSON bytes.
+// A (delegate) Signer isn't checked, see StatementFromBytes.
 func unmarshalJSON(b []byte) (*Statement, error) {
 	if len(b) < 97 {
 		return nil, errors.Errorf("not enough bytes for statement")
@@ -224,6 +499,18 @@ func unmarshalJSON(b []byte) (*Statement, error) {
 		return nil, err
 	}
 	ts := tsutil.ParseMillis(stf.Timestamp)
+	tsaTime := tsutil.ParseMillis(stf.TSATime)
+	coKIDs, coSigs, err := parseCosigs(stf.CoKIDs, stf.CoSigs)
+	if err != nil {
+		return nil, err
+	}
+	var signer ID
+	if stf.Signer != "" {
+		signer, err = ParseID(stf.Signer)
+		if err != nil {
+			return nil, err
+		}
+	}
 
 	if !bytes.Equal(stf.Sig, sigBytes) {
 		return nil, errors.Errorf("sig bytes mismatch")
@@ -237,12 +524,87 @@ func unmarshalJSON(b []byte) (*Statement, error) {
 		Prev:      stf.Prev,
 		Revoke:    stf.Revoke,
 		Seq:       stf.Seq,
+		Signer:    signer,
 		Timestamp: ts,
 		Type:      stf.Type,
+		Version:   stf.Version,
+		Zip:       stf.Zip,
+		Context:   stf.Context,
+		CoKIDs:    coKIDs,
+		CoSigs:    coSigs,
+
+		TSASig:       stf.TSASig,
+		TSATimestamp: tsaTime,
 	}
-	if err := st.VerifySpecific(bytesToSign); err != nil {
+	if err := st.verifySpecific(bytesToSign); err != nil {
 		return nil, err
 	}
 
 	return st, nil
 }
+
+func parseCosigs(kids string, sigs string) ([]ID, [][]byte, error) {
+	if kids == "" {
+		if sigs != "" {
+			return nil, nil, errors.Errorf("invalid cosignatures")
+		}
+		return nil, nil, nil
+	}
+	coKIDs := []ID{}
+	for _, s := range strings.Split(kids, ",") {
+		kid, err := ParseID(s)
+		if err != nil {
+			return nil, nil, err
+		}
+		coKIDs = append(coKIDs, kid)
+	}
+	coSigs := [][]byte{}
+	for _, s := range strings.Split(sigs, ",") {
+		b, err := encoding.Decode(s, encoding.Base64)
+		if err != nil {
+			return nil, nil, err
+		}
+		coSigs = append(coSigs, b)
+	}
+	return coKIDs, coSigs, nil
+}
+
+// CountersignStatement adds a countersignature from a timestamp authority (TSA)
+// key to a signed statement, proving it existed at time ts.
+// The TSA signs the statement bytes (including the statement signature) and
+// its own timestamp. The countersignature isn't part of the bytes the
+// statement signer signs, or of the SigchainHash.
+func CountersignStatement(st *Statement, tsaKey *EdX25519Key, ts time.Time) error {
+	if st.TSASig != nil {
+		return errors.Errorf("countersignature already set")
+	}
+	if ts.IsZero() {
+		return errors.Errorf("no countersignature timestamp")
+	}
+	if err := st.verifySignature(); err != nil {
+		return err
+	}
+	st.TSATimestamp = ts
+	st.TSASig = tsaKey.SignDetached(st.countersignBytes())
+	return nil
+}
+
+// VerifyCountersignature verifies the statement was countersigned by the
+// timestamp authority (TSA) key.
+func (s *Statement) VerifyCountersignature(tsaPub *EdX25519PublicKey) error {
+	if len(s.TSASig) == 0 || s.TSATimestamp.IsZero() {
+		return errors.Errorf("missing countersignature")
+	}
+	if err := s.Verify(); err != nil {
+		return err
+	}
+	return tsaPub.VerifyDetached(s.TSASig, s.countersignBytes())
+}
+
+// countersignBytes are the statement bytes (with sig) and TSA timestamp,
+// without the TSA sig.
+func (s *Statement) countersignBytes() []byte {
+	st := *s
+	st.TSASig = nil
+	return statementBytes(&st, st.Sig, true)
+}
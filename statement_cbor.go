@@ -0,0 +1,343 @@
+package keys
+
+import (
+	"bytes"
+	"encoding/binary"
+	"sort"
+
+	"github.com/keys-pub/keys/tsutil"
+	"github.com/pkg/errors"
+)
+
+// CBOR (RFC 8949) serialization for statements.
+// This is only an alternative transport encoding, the signature is over the
+// JSON bytes (see BytesToSign).
+// The encoding is deterministic: the same fields as the JSON serialization
+// (with binary values as byte strings), definite lengths, integers in their
+// shortest form, and map keys in length-first, then bytewise, order.
+
+const (
+	cborUint  = 0
+	cborBytes = 2
+	cborText  = 3
+	cborArray = 4
+	cborMap   = 5
+)
+
+// MarshalCBOR returns the deterministic CBOR encoding of the Statement.
+// Like MarshalJSON, a delegate (Signer) isn't checked.
+func (s *Statement) MarshalCBOR() ([]byte, error) {
+	if err := s.verifySignature(); err != nil {
+		return nil, err
+	}
+	return statementCBOR(s), nil
+}
+
+// UnmarshalCBOR unmarshals a statement from CBOR.
+// Like UnmarshalJSON, a delegate (Signer) isn't checked.
+func (s *Statement) UnmarshalCBOR(b []byte) error {
+	st, err := statementFromCBOR(b)
+	if err != nil {
+		return err
+	}
+	*s = *st
+	return nil
+}
+
+// StatementFromCBOR returns a verified Statement from its CBOR encoding.
+// The bytes must be the deterministic encoding (see MarshalCBOR).
+// A statement signed by a delegate (Signer) is rejected (see Verify).
+func StatementFromCBOR(b []byte) (*Statement, error) {
+	st, err := statementFromCBOR(b)
+	if err != nil {
+		return nil, err
+	}
+	if err := st.verifyNoSigner(); err != nil {
+		return nil, err
+	}
+	return st, nil
+}
+
+func statementFromCBOR(b []byte) (*Statement, error) {
+	d := &cborDecoder{b: b}
+	n, err := d.head(cborMap)
+	if err != nil {
+		return nil, err
+	}
+	st := &Statement{}
+	for i := 0; i < n; i++ {
+		key, err := d.text()
+		if err != nil {
+			return nil, err
+		}
+		if err := d.field(st, key); err != nil {
+			return nil, err
+		}
+	}
+	if len(d.b) != 0 {
+		return nil, errors.Errorf("invalid statement cbor: trailing bytes")
+	}
+	if !bytes.Equal(statementCBOR(st), b) {
+		return nil, errors.Errorf("statement cbor is not canonical")
+	}
+	if err := st.verifySignature(); err != nil {
+		return nil, err
+	}
+	return st, nil
+}
+
+type cborEntry struct {
+	key   string
+	value []byte
+}
+
+func statementCBOR(st *Statement) []byte {
+	entries := []cborEntry{}
+	add := func(key string, value []byte) {
+		entries = append(entries, cborEntry{key: key, value: value})
+	}
+	add(".sig", cborEncodeBytes(st.Sig))
+	if len(st.CoKIDs) != 0 {
+		kids := cborEncodeHead(cborArray, uint64(len(st.CoKIDs)))
+		for _, kid := range st.CoKIDs {
+			kids = append(kids, cborEncodeText(kid.String())...)
+		}
+		add("co.kids", kids)
+		sigs := cborEncodeHead(cborArray, uint64(len(st.CoSigs)))
+		for _, sig := range st.CoSigs {
+			sigs = append(sigs, cborEncodeBytes(sig)...)
+		}
+		add("co.sigs", sigs)
+	}
+	if st.Context != "" {
+		add("ctx", cborEncodeText(st.Context))
+	}
+	if len(st.Data) != 0 {
+		add("data", cborEncodeBytes(st.Data))
+	}
+	add("kid", cborEncodeText(st.KID.String()))
+	if len(st.Nonce) != 0 {
+		add("nonce", cborEncodeBytes(st.Nonce))
+	}
+	if len(st.Prev) != 0 {
+		add("prev", cborEncodeBytes(st.Prev))
+	}
+	if st.Revoke != 0 {
+		add("revoke", cborEncodeHead(cborUint, uint64(st.Revoke)))
+	}
+	if st.Seq != 0 {
+		add("seq", cborEncodeHead(cborUint, uint64(st.Seq)))
+	}
+	if st.Signer != "" {
+		add("signer", cborEncodeText(st.Signer.String()))
+	}
+	if !st.Timestamp.IsZero() {
+		add("ts", cborEncodeHead(cborUint, uint64(tsutil.Millis(st.Timestamp))))
+	}
+	if !st.TSATimestamp.IsZero() {
+		if len(st.TSASig) != 0 {
+			add("tsa.sig", cborEncodeBytes(st.TSASig))
+		}
+		add("tsa.ts", cborEncodeHead(cborUint, uint64(tsutil.Millis(st.TSATimestamp))))
+	}
+	if st.Type != "" {
+		add("type", cborEncodeText(st.Type))
+	}
+	if st.Version != 0 {
+		add("v", cborEncodeHead(cborUint, uint64(st.Version)))
+	}
+	if st.Zip != "" {
+		add("zip", cborEncodeText(st.Zip))
+	}
+
+	sort.Slice(entries, func(i, j int) bool {
+		if len(entries[i].key) != len(entries[j].key) {
+			return len(entries[i].key) < len(entries[j].key)
+		}
+		return entries[i].key < entries[j].key
+	})
+	out := cborEncodeHead(cborMap, uint64(len(entries)))
+	for _, e := range entries {
+		out = append(out, cborEncodeText(e.key)...)
+		out = append(out, e.value...)
+	}
+	return out
+}
+
+func cborEncodeHead(major byte, n uint64) []byte {
+	m := major << 5
+	switch {
+	case n < 24:
+		return []byte{m | byte(n)}
+	case n <= 0xff:
+		return []byte{m | 24, byte(n)}
+	case n <= 0xffff:
+		b := []byte{m | 25, 0, 0}
+		binary.BigEndian.PutUint16(b[1:], uint16(n))
+		return b
+	case n <= 0xffffffff:
+		b := []byte{m | 26, 0, 0, 0, 0}
+		binary.BigEndian.PutUint32(b[1:], uint32(n))
+		return b
+	default:
+		b := []byte{m | 27, 0, 0, 0, 0, 0, 0, 0, 0}
+		binary.BigEndian.PutUint64(b[1:], n)
+		return b
+	}
+}
+
+func cborEncodeBytes(b []byte) []byte {
+	return append(cborEncodeHead(cborBytes, uint64(len(b))), b...)
+}
+
+func cborEncodeText(s string) []byte {
+	return append(cborEncodeHead(cborText, uint64(len(s))), s...)
+}
+
+type cborDecoder struct {
+	b []byte
+}
+
+func (d *cborDecoder) uint(major byte) (uint64, error) {
+	if len(d.b) == 0 {
+		return 0, errors.Errorf("invalid statement cbor: unexpected end")
+	}
+	if d.b[0]>>5 != major {
+		return 0, errors.Errorf("invalid statement cbor: unexpected type")
+	}
+	info := d.b[0] & 0x1f
+	d.b = d.b[1:]
+	size := 0
+	switch {
+	case info < 24:
+		return uint64(info), nil
+	case info == 24:
+		size = 1
+	case info == 25:
+		size = 2
+	case info == 26:
+		size = 4
+	case info == 27:
+		size = 8
+	default:
+		return 0, errors.Errorf("invalid statement cbor: unsupported length")
+	}
+	if len(d.b) < size {
+		return 0, errors.Errorf("invalid statement cbor: unexpected end")
+	}
+	var n uint64
+	for _, c := range d.b[:size] {
+		n = n<<8 | uint64(c)
+	}
+	d.b = d.b[size:]
+	return n, nil
+}
+
+func (d *cborDecoder) head(major byte) (int, error) {
+	n, err := d.uint(major)
+	if err != nil {
+		return 0, err
+	}
+	if n > uint64(len(d.b)) {
+		return 0, errors.Errorf("invalid statement cbor: unexpected end")
+	}
+	return int(n), nil
+}
+
+func (d *cborDecoder) int() (int, error) {
+	n, err := d.uint(cborUint)
+	if err != nil {
+		return 0, err
+	}
+	if n > 1<<53 {
+		return 0, errors.Errorf("invalid statement cbor: int too large")
+	}
+	return int(n), nil
+}
+
+func (d *cborDecoder) bytes() ([]byte, error) {
+	n, err := d.head(cborBytes)
+	if err != nil {
+		return nil, err
+	}
+	b := d.b[:n]
+	d.b = d.b[n:]
+	return b, nil
+}
+
+func (d *cborDecoder) text() (string, error) {
+	n, err := d.head(cborText)
+	if err != nil {
+		return "", err
+	}
+	s := string(d.b[:n])
+	d.b = d.b[n:]
+	return s, nil
+}
+
+func (d *cborDecoder) id() (ID, error) {
+	s, err := d.text()
+	if err != nil {
+		return "", err
+	}
+	return ParseID(s)
+}
+
+func (d *cborDecoder) field(st *Statement, key string) error {
+	var err error
+	switch key {
+	case ".sig":
+		st.Sig, err = d.bytes()
+	case "co.kids":
+		var n int
+		n, err = d.head(cborArray)
+		for i := 0; i < n && err == nil; i++ {
+			var kid ID
+			kid, err = d.id()
+			st.CoKIDs = append(st.CoKIDs, kid)
+		}
+	case "co.sigs":
+		var n int
+		n, err = d.head(cborArray)
+		for i := 0; i < n && err == nil; i++ {
+			var sig []byte
+			sig, err = d.bytes()
+			st.CoSigs = append(st.CoSigs, sig)
+		}
+	case "ctx":
+		st.Context, err = d.text()
+	case "data":
+		st.Data, err = d.bytes()
+	case "kid":
+		st.KID, err = d.id()
+	case "nonce":
+		st.Nonce, err = d.bytes()
+	case "prev":
+		st.Prev, err = d.bytes()
+	case "revoke":
+		st.Revoke, err = d.int()
+	case "seq":
+		st.Seq, err = d.int()
+	case "signer":
+		st.Signer, err = d.id()
+	case "ts":
+		var ts int
+		ts, err = d.int()
+		st.Timestamp = tsutil.ParseMillis(int64(ts))
+	case "tsa.sig":
+		st.TSASig, err = d.bytes()
+	case "tsa.ts":
+		var ts int
+		ts, err = d.int()
+		st.TSATimestamp = tsutil.ParseMillis(int64(ts))
+	case "type":
+		st.Type, err = d.text()
+	case "v":
+		st.Version, err = d.int()
+	case "zip":
+		st.Zip, err = d.text()
+	default:
+		return errors.Errorf("invalid statement cbor: unknown field %s", key)
+	}
+	return err
+}
@@ -0,0 +1,69 @@
+package keys_test
+
+import (
+	"encoding/hex"
+	"strings"
+	"testing"
+
+	"github.com/keys-pub/keys"
+	"github.com/keys-pub/keys/tsutil"
+	"github.com/stretchr/testify/require"
+)
+
+func TestStatementCBORGolden(t *testing.T) {
+	for _, path := range []string{"testdata/sc1.spew", "testdata/sc2.spew"} {
+		lines := strings.Split(strings.TrimSpace(string(testdata(t, path))), "\n")
+		for _, line := range lines {
+			spl := strings.SplitN(line, " ", 2)
+			require.Equal(t, 2, len(spl))
+			st, err := keys.StatementFromBytes([]byte(spl[1]))
+			require.NoError(t, err)
+
+			b, err := st.MarshalCBOR()
+			require.NoError(t, err)
+			out, err := keys.StatementFromCBOR(b)
+			require.NoError(t, err)
+			js, err := out.Bytes()
+			require.NoError(t, err)
+			require.Equal(t, spl[1], string(js))
+
+			// Deterministic
+			b2, err := out.MarshalCBOR()
+			require.NoError(t, err)
+			require.Equal(t, b, b2)
+		}
+	}
+}
+
+func TestStatementCBOR(t *testing.T) {
+	clock := tsutil.NewTestClock()
+	alice := keys.NewEdX25519KeyFromSeed(testSeed(0x01))
+	sc := keys.NewSigchain(alice.ID())
+	st, err := keys.NewSigchainStatement(sc, []byte("hi"), alice, "test", clock.Now())
+	require.NoError(t, err)
+
+	b, err := st.MarshalCBOR()
+	require.NoError(t, err)
+	// Map (6 entries), shortest key ("ts") first
+	require.Equal(t, "a6627473", hex.EncodeToString(b[:4]))
+
+	var out keys.Statement
+	err = out.UnmarshalCBOR(b)
+	require.NoError(t, err)
+	require.True(t, keys.StatementsEqual(st, &out))
+
+	// Tampered
+	b[len(b)-1] ^= 0x01
+	_, err = keys.StatementFromCBOR(b)
+	require.EqualError(t, err, "verify failed")
+
+	// Trailing bytes
+	b, err = st.MarshalCBOR()
+	require.NoError(t, err)
+	_, err = keys.StatementFromCBOR(append(b, 0x00))
+	require.EqualError(t, err, "invalid statement cbor: trailing bytes")
+
+	// Not canonical (uint in a longer form)
+	_, err = keys.StatementFromCBOR([]byte{0xb8, 0x01, 0x63, 's', 'e', 'q', 0x01})
+	require.EqualError(t, err, "statement cbor is not canonical")
+}
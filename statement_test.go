@@ -3,6 +3,7 @@ package keys_test
 import (
 	"bytes"
 	"encoding/json"
+	"strings"
 	"testing"
 
 	"github.com/keys-pub/keys"
@@ -141,6 +142,311 @@ func TestStatementJSON(t *testing.T) {
 	require.Equal(t, revoke.BytesToSign(), stOut2.BytesToSign())
 }
 
+func TestCountersignStatement(t *testing.T) {
+	clock := tsutil.NewTestClock()
+	sk := keys.NewEdX25519KeyFromSeed(testSeed(0x01))
+	tsa := keys.NewEdX25519KeyFromSeed(testSeed(0x02))
+
+	sc := keys.NewSigchain(sk.ID())
+	st, err := keys.NewSigchainStatement(sc, bytes.Repeat([]byte{0x01}, 16), sk, "test", clock.Now())
+	require.NoError(t, err)
+	err = sc.Add(st)
+	require.NoError(t, err)
+	prevHash, err := keys.SigchainHash(st)
+	require.NoError(t, err)
+
+	err = st.VerifyCountersignature(tsa.PublicKey())
+	require.EqualError(t, err, "missing countersignature")
+
+	err = keys.CountersignStatement(st, tsa, clock.Now())
+	require.NoError(t, err)
+	err = st.VerifyCountersignature(tsa.PublicKey())
+	require.NoError(t, err)
+	err = keys.CountersignStatement(st, tsa, clock.Now())
+	require.EqualError(t, err, "countersignature already set")
+
+	// Countersignature doesn't change the sigchain hash
+	hash, err := keys.SigchainHash(st)
+	require.NoError(t, err)
+	require.Equal(t, prevHash, hash)
+
+	b, err := st.Bytes()
+	require.NoError(t, err)
+	expected := `{".sig":"+H4VoHKAzH8e7Fn0LTtabx1MSpmnEY7xejxzMLr13Cfu1uvj4LKDKJ8AWLP38OU+HDSqO9JYkR+MtM/o7JvzAw==","data":"AQEBAQEBAQEBAQEBAQEBAQ==","kid":"kex132yw8ht5p8cetl2jmvknewjawt9xwzdlrk2pyxlnwjyqrdq0dawqqph077","seq":1,"ts":1234567890001,"tsa.sig":"GkYclSOSEmXqRjM33G3aRnF/5/Lm//5UHDWc0SK+2h65gZTa7gZP9OhLO7Egi/GNKRjVlAsDms+NGL+7kFMtCQ==","tsa.ts":1234567890002,"type":"test"}`
+	require.Equal(t, expected, string(b))
+
+	var stOut keys.Statement
+	err = json.Unmarshal(b, &stOut)
+	require.NoError(t, err)
+	require.Equal(t, st.TSASig, stOut.TSASig)
+	require.Equal(t, st.TSATimestamp, stOut.TSATimestamp)
+	err = stOut.VerifyCountersignature(tsa.PublicKey())
+	require.NoError(t, err)
+
+	// Wrong TSA key
+	err = stOut.VerifyCountersignature(sk.PublicKey())
+	require.EqualError(t, err, "verify failed")
+
+	// Tampered countersignature timestamp
+	stOut.TSATimestamp = clock.Now()
+	err = stOut.VerifyCountersignature(tsa.PublicKey())
+	require.EqualError(t, err, "verify failed")
+
+	// Tampered countersignature
+	stOut.TSATimestamp = st.TSATimestamp
+	stOut.TSASig[0] ^= 0xFF
+	err = stOut.VerifyCountersignature(tsa.PublicKey())
+	require.EqualError(t, err, "verify failed")
+}
+
+func TestMultiStatement(t *testing.T) {
+	clock := tsutil.NewTestClock()
+	alice := keys.NewEdX25519KeyFromSeed(testSeed(0x01))
+	bob := keys.NewEdX25519KeyFromSeed(testSeed(0x02))
+	charlie := keys.NewEdX25519KeyFromSeed(testSeed(0x03))
+
+	sc := keys.NewSigchain(alice.ID())
+	st, err := keys.NewSigchainMultiStatement(sc, []byte("hi"), []*keys.EdX25519Key{alice, bob}, "test", clock.Now())
+	require.NoError(t, err)
+	require.Equal(t, []keys.ID{bob.ID()}, st.CoKIDs)
+	require.Equal(t, keys.StatementVersionMultiSig, st.Version)
+	err = sc.Add(st)
+	require.NoError(t, err)
+
+	b, err := st.Bytes()
+	require.NoError(t, err)
+	require.Contains(t, string(b), `"co.kids":"`+bob.ID().String()+`","co.sigs":"`)
+	require.Contains(t, string(b), `"v":2`)
+	require.NotContains(t, string(st.BytesToSign()), `"co.sigs"`)
+
+	out, err := keys.StatementFromBytes(b)
+	require.NoError(t, err)
+	require.True(t, keys.StatementsEqual(st, out))
+	require.Equal(t, st.CoSigs, out.CoSigs)
+	require.Equal(t, keys.StatementVersionMultiSig, out.Version)
+
+	cb, err := st.MarshalCBOR()
+	require.NoError(t, err)
+	cout, err := keys.StatementFromCBOR(cb)
+	require.NoError(t, err)
+	require.Equal(t, keys.StatementVersionMultiSig, cout.Version)
+	require.Equal(t, st.CoSigs, cout.CoSigs)
+
+	// Missing version
+	_, err = keys.StatementFromBytes(bytes.Replace(b, []byte(`,"v":2`), nil, 1))
+	require.EqualError(t, err, "invalid statement version 0")
+
+	// Next statement (single sig) links to the multi sig statement
+	st2, err := keys.NewSigchainStatement(sc, []byte("hi2"), alice, "test", clock.Now())
+	require.NoError(t, err)
+	err = sc.Add(st2)
+	require.NoError(t, err)
+
+	// Missing cosignature
+	st3, err := keys.NewSigchainStatement(sc, []byte("hi3"), alice, "test", clock.Now())
+	require.NoError(t, err)
+	st3.Sig = nil
+	st3.CoKIDs = []keys.ID{bob.ID(), charlie.ID()}
+	err = st3.Sign(alice)
+	require.EqualError(t, err, "invalid statement version 0")
+	st3.Version = keys.StatementVersionMultiSig
+	err = st3.Sign(alice)
+	require.NoError(t, err)
+	err = st3.Cosign(bob)
+	require.NoError(t, err)
+	err = st3.Verify()
+	require.EqualError(t, err, "missing cosignature")
+	err = sc.Add(st3)
+	require.EqualError(t, err, "missing cosignature")
+	err = st3.Cosign(charlie)
+	require.NoError(t, err)
+	err = st3.Verify()
+	require.NoError(t, err)
+	err = st3.Cosign(charlie)
+	require.EqualError(t, err, "cosignature already set")
+	err = st3.Cosign(alice)
+	require.EqualError(t, err, "cosign failed: key not a cosigner")
+
+	// Cosignature from the wrong key
+	st3.CoSigs[1] = st3.CoSigs[0]
+	err = st3.Verify()
+	require.EqualError(t, err, "verify failed")
+
+	// Duplicate signer
+	_, err = keys.NewSigchainMultiStatement(sc, []byte("hi"), []*keys.EdX25519Key{alice, alice}, "test", clock.Now())
+	require.EqualError(t, err, "duplicate statement signer "+alice.ID().String())
+	_, err = keys.NewSigchainMultiStatement(sc, []byte("hi"), []*keys.EdX25519Key{bob, alice}, "test", clock.Now())
+	require.EqualError(t, err, "invalid sigchain public key")
+
+	// Signer listed in its own co.kids (signed without Sign)
+	forged := &keys.Statement{
+		KID:     alice.ID(),
+		Data:    []byte("hi3"),
+		Seq:     3,
+		Prev:    st3.Prev,
+		Type:    "test",
+		CoKIDs:  []keys.ID{alice.ID()},
+		Version: keys.StatementVersionMultiSig,
+	}
+	sig := alice.SignDetached(forged.BytesToSign())
+	forged.Sig = sig
+	forged.CoSigs = [][]byte{sig}
+	err = forged.Verify()
+	require.EqualError(t, err, "duplicate statement signer "+alice.ID().String())
+	err = sc.Add(forged)
+	require.EqualError(t, err, "duplicate statement signer "+alice.ID().String())
+	_, err = forged.MarshalJSON()
+	require.EqualError(t, err, "duplicate statement signer "+alice.ID().String())
+
+	sigEnc := encoding.MustEncode(sig, encoding.Base64)
+	forgedBytes := strings.Replace(string(forged.BytesToSign()), `".sig":""`, `".sig":"`+sigEnc+`"`, 1)
+	forgedBytes = strings.Replace(forgedBytes, `"co.kids":"`+alice.ID().String()+`"`, `"co.kids":"`+alice.ID().String()+`","co.sigs":"`+sigEnc+`"`, 1)
+	_, err = keys.StatementFromBytes([]byte(forgedBytes))
+	require.EqualError(t, err, "duplicate statement signer "+alice.ID().String())
+}
+
+func TestVerifyStatement(t *testing.T) {
+	clock := tsutil.NewTestClock()
+	alice := keys.NewEdX25519KeyFromSeed(testSeed(0x01))
+	bob := keys.NewEdX25519KeyFromSeed(testSeed(0x02))
+	sc := keys.NewSigchain(alice.ID())
+	st, err := keys.NewSigchainStatement(sc, []byte("hi"), alice, "test", clock.Now())
+	require.NoError(t, err)
+
+	err = keys.VerifyStatement(st, alice.PublicKey())
+	require.NoError(t, err)
+
+	err = keys.VerifyStatement(st, bob.PublicKey())
+	require.EqualError(t, err, "invalid statement kid")
+
+	st.Data = []byte("hi2")
+	err = keys.VerifyStatement(st, alice.PublicKey())
+	require.EqualError(t, err, "verify failed")
+
+	// Signed by bob, for alice
+	forged, err := keys.NewSigchainDelegatedStatement(sc, []byte("hi"), bob, "test", clock.Now())
+	require.NoError(t, err)
+	err = keys.VerifyStatement(forged, alice.PublicKey())
+	require.EqualError(t, err, "unverified statement signer "+bob.ID().String())
+	forged.Signer = ""
+	err = keys.VerifyStatement(forged, alice.PublicKey())
+	require.EqualError(t, err, "verify failed")
+}
+
+func TestStatementsEqual(t *testing.T) {
+	clock := tsutil.NewTestClock()
+	sk := keys.NewEdX25519KeyFromSeed(testSeed(0x01))
+
+	sc := keys.NewSigchain(sk.ID())
+	st, err := keys.NewSigchainStatement(sc, bytes.Repeat([]byte{0x01}, 16), sk, "test", clock.Now())
+	require.NoError(t, err)
+	err = sc.Add(st)
+	require.NoError(t, err)
+	require.True(t, keys.StatementsEqual(st, st))
+
+	// From JSON
+	b, err := json.Marshal(st)
+	require.NoError(t, err)
+	var stOut keys.Statement
+	err = json.Unmarshal(b, &stOut)
+	require.NoError(t, err)
+	require.True(t, keys.StatementsEqual(st, &stOut))
+
+	// Empty optional fields
+	stOut.Nonce = []byte{}
+	stOut.Prev = []byte{}
+	require.True(t, keys.StatementsEqual(st, &stOut))
+
+	rv, err := sc.Revoke(1, sk)
+	require.NoError(t, err)
+	rvCopy := *rv
+	rvCopy.Data = []byte{}
+	require.True(t, keys.StatementsEqual(rv, &rvCopy))
+
+	// Different
+	require.False(t, keys.StatementsEqual(st, rv))
+	stOut.Type = "test2"
+	require.False(t, keys.StatementsEqual(st, &stOut))
+	stOut.Type = st.Type
+	stOut.Sig = bytes.Repeat([]byte{0x01}, 64)
+	require.False(t, keys.StatementsEqual(st, &stOut))
+	require.False(t, keys.StatementsEqual(st, nil))
+	require.True(t, keys.StatementsEqual(nil, nil))
+}
+
+func TestStatementFromBytes(t *testing.T) {
+	clock := tsutil.NewTestClock()
+	sk := keys.NewEdX25519KeyFromSeed(testSeed(0x01))
+	sc := keys.NewSigchain(sk.ID())
+
+	st, err := keys.NewSigchainStatement(sc, bytes.Repeat([]byte{0x01}, 16), sk, "test", clock.Now())
+	require.NoError(t, err)
+	b, err := st.Bytes()
+	require.NoError(t, err)
+
+	out, err := keys.StatementFromBytes(b)
+	require.NoError(t, err)
+	require.True(t, keys.StatementsEqual(st, out))
+
+	// Unknown field
+	foo := `{".sig":"+H4VoHKAzH8e7Fn0LTtabx1MSpmnEY7xejxzMLr13Cfu1uvj4LKDKJ8AWLP38OU+HDSqO9JYkR+MtM/o7JvzAw==","data":"AQEBAQEBAQEBAQEBAQEBAQ==","foo":"bar","kid":"kex132yw8ht5p8cetl2jmvknewjawt9xwzdlrk2pyxlnwjyqrdq0dawqqph077","seq":1,"ts":1234567890001,"type":"test"}`
+	_, err = keys.StatementFromBytes([]byte(foo))
+	require.EqualError(t, err, "statement bytes failed to match specific serialization")
+	var stOut keys.Statement
+	err = json.Unmarshal([]byte(foo), &stOut)
+	require.EqualError(t, err, "statement bytes failed to match specific serialization")
+
+	// Reordered fields
+	reordered := `{".sig":"+H4VoHKAzH8e7Fn0LTtabx1MSpmnEY7xejxzMLr13Cfu1uvj4LKDKJ8AWLP38OU+HDSqO9JYkR+MtM/o7JvzAw==","kid":"kex132yw8ht5p8cetl2jmvknewjawt9xwzdlrk2pyxlnwjyqrdq0dawqqph077","data":"AQEBAQEBAQEBAQEBAQEBAQ==","seq":1,"ts":1234567890001,"type":"test"}`
+	_, err = keys.StatementFromBytes([]byte(reordered))
+	require.EqualError(t, err, "statement bytes failed to match specific serialization")
+}
+
+func TestStatementFromBytesNonCanonical(t *testing.T) {
+	clock := tsutil.NewTestClock()
+	sk := keys.NewEdX25519KeyFromSeed(testSeed(0x01))
+	sc := keys.NewSigchain(sk.ID())
+
+	st, err := keys.NewSigchainStatement(sc, bytes.Repeat([]byte{0x01}, 16), sk, "test", clock.Now())
+	require.NoError(t, err)
+	b, err := st.Bytes()
+	require.NoError(t, err)
+
+	var indented bytes.Buffer
+	err = json.Indent(&indented, b, "", "  ")
+	require.NoError(t, err)
+
+	// Same fields and signature, different serialization
+	seq := `"seq":1,`
+	ts := `"ts":1234567890001,`
+	require.Contains(t, string(b), seq+ts)
+	cases := map[string][]byte{
+		"reordered":         bytes.Replace(b, []byte(seq+ts), []byte(ts+seq), 1),
+		"space after colon": bytes.Replace(b, []byte(`"seq":1`), []byte(`"seq": 1`), 1),
+		"space after comma": bytes.Replace(b, []byte(seq), []byte(seq+" "), 1),
+	}
+	for name, nb := range cases {
+		require.NotEqual(t, b, nb, name)
+		_, err = keys.StatementFromBytes(nb)
+		require.EqualError(t, err, "statement bytes failed to match specific serialization", name)
+		var stOut keys.Statement
+		err = json.Unmarshal(nb, &stOut)
+		require.EqualError(t, err, "statement bytes failed to match specific serialization", name)
+	}
+
+	// Indented, or whitespace around the statement (which json.Unmarshal
+	// strips before calling UnmarshalJSON)
+	_, err = keys.StatementFromBytes(indented.Bytes())
+	require.Error(t, err)
+	var stOut keys.Statement
+	err = json.Unmarshal(indented.Bytes(), &stOut)
+	require.Error(t, err)
+	_, err = keys.StatementFromBytes(append([]byte(" "), b...))
+	require.Error(t, err)
+	_, err = keys.StatementFromBytes(append(append([]byte{}, b...), '\n'))
+	require.EqualError(t, err, "statement bytes failed to match specific serialization")
+}
+
 func TestStatementSpecificSerialization(t *testing.T) {
 	clock := tsutil.NewTestClock()
 	sk := keys.NewEdX25519KeyFromSeed(testSeed(0x01))
@@ -211,6 +517,7 @@ func TestStatementSpecificSerialization(t *testing.T) {
 	require.Equal(t, revoke.Type, stOut2.Type)
 	require.Equal(t, revoke.BytesToSign(), stOut2.BytesToSign())
 }
+
 func TestBadStatements(t *testing.T) {
 	var empty keys.Statement
 	var err error
@@ -269,3 +576,23 @@ func TestStatementKeyURL(t *testing.T) {
 	require.Equal(t, "kex132yw8ht5p8cetl2jmvknewjawt9xwzdlrk2pyxlnwjyqrdq0dawqqph077-000000000000001", keys.StatementID(st.KID, st.Seq))
 	require.Equal(t, "/kex132yw8ht5p8cetl2jmvknewjawt9xwzdlrk2pyxlnwjyqrdq0dawqqph077/1", st.URL())
 }
+
+func TestStatementBytesToSignGolden(t *testing.T) {
+	clock := tsutil.NewTestClock()
+	sk := keys.NewEdX25519KeyFromSeed(testSeed(0x01))
+
+	sc := keys.NewSigchain(sk.ID())
+	st, err := keys.NewSigchainStatement(sc, []byte("hi! 🤓"), sk, "emoji", clock.Now())
+	require.NoError(t, err)
+	err = sc.Add(st)
+	require.NoError(t, err)
+	st2, err := keys.NewSigchainStatement(sc, []byte("2nd message"), sk, "test", clock.Now())
+	require.NoError(t, err)
+
+	out := string(st.BytesToSign()) + "\n" + string(st2.BytesToSign()) + "\n"
+	require.Equal(t, string(testdata(t, "testdata/statement.golden")), out)
+
+	// Signature is over BytesToSign (without .sig)
+	err = sk.PublicKey().VerifyDetached(st.Sig, st.BytesToSign())
+	require.NoError(t, err)
+}
@@ -0,0 +1,94 @@
+package keys
+
+import (
+	"bytes"
+	"compress/gzip"
+	"io"
+	"io/ioutil"
+	"time"
+
+	"github.com/pkg/errors"
+)
+
+// statementZipMinSize is the minimum data size to compress, smaller data is
+// left uncompressed.
+const statementZipMinSize = 512
+
+// StatementZipMaxSize is the maximum uncompressed data size for a compressed
+// statement, so a small statement can't decompress to an arbitrarily large
+// size.
+const StatementZipMaxSize = 16 * 1024 * 1024
+
+// NewSigchainStatementCompressed creates a signed Statement to be added to the
+// Sigchain, with gzip compressed data (Zip is "gz").
+// The signature covers the compressed data.
+// If the data is small, or doesn't get smaller, it isn't compressed.
+func NewSigchainStatementCompressed(sc *Sigchain, b []byte, sk *EdX25519Key, typ string, ts time.Time) (*Statement, error) {
+	if sc == nil {
+		return nil, errors.Errorf("no sigchain specified")
+	}
+	if sc.KID() != sk.ID() {
+		return nil, errors.Errorf("invalid sigchain public key")
+	}
+	if len(b) > StatementZipMaxSize {
+		return nil, errors.Errorf("statement data too large to compress")
+	}
+	zip := ""
+	if len(b) >= statementZipMinSize {
+		gz, err := gzipBytes(b)
+		if err != nil {
+			return nil, err
+		}
+		if len(gz) < len(b) {
+			b = gz
+			zip = "gz"
+		}
+	}
+	st, err := unsignedSigchainStatement(sc.Last(), b, sk.ID(), typ, ts, sc.context)
+	if err != nil {
+		return nil, err
+	}
+	st.Zip = zip
+	if err := st.Sign(sk); err != nil {
+		return nil, err
+	}
+	return st, nil
+}
+
+// UncompressedData returns Data, decompressed if Zip is set.
+// Returns an error if the uncompressed data is larger than
+// StatementZipMaxSize.
+func (s *Statement) UncompressedData() ([]byte, error) {
+	switch s.Zip {
+	case "":
+		return s.Data, nil
+	case "gz":
+		r, err := gzip.NewReader(bytes.NewReader(s.Data))
+		if err != nil {
+			return nil, err
+		}
+		defer r.Close()
+		b, err := ioutil.ReadAll(io.LimitReader(r, StatementZipMaxSize+1))
+		if err != nil {
+			return nil, err
+		}
+		if len(b) > StatementZipMaxSize {
+			return nil, errors.Errorf("statement uncompressed data too large")
+		}
+		return b, nil
+	default:
+		return nil, errors.Errorf("unsupported statement zip %q", s.Zip)
+	}
+}
+
+func gzipBytes(b []byte) ([]byte, error) {
+	var buf bytes.Buffer
+	w := gzip.NewWriter(&buf)
+	if _, err := w.Write(b); err != nil {
+		return nil, err
+	}
+	if err := w.Close(); err != nil {
+		return nil, err
+	}
+	return buf.Bytes(), nil
+}
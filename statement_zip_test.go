@@ -0,0 +1,80 @@
+package keys_test
+
+import (
+	"bytes"
+	"compress/gzip"
+	"testing"
+
+	"github.com/keys-pub/keys"
+	"github.com/keys-pub/keys/tsutil"
+	"github.com/stretchr/testify/require"
+)
+
+func TestStatementCompressed(t *testing.T) {
+	clock := tsutil.NewTestClock()
+	alice := keys.NewEdX25519KeyFromSeed(testSeed(0x01))
+	sc := keys.NewSigchain(alice.ID())
+
+	data := bytes.Repeat([]byte(`{"name":"alice","value":1}`), 100)
+	st, err := keys.NewSigchainStatementCompressed(sc, data, alice, "test", clock.Now())
+	require.NoError(t, err)
+	require.Equal(t, "gz", st.Zip)
+	require.True(t, len(st.Data) < len(data))
+	err = sc.Add(st)
+	require.NoError(t, err)
+
+	b, err := st.Bytes()
+	require.NoError(t, err)
+	out, err := keys.StatementFromBytes(b)
+	require.NoError(t, err)
+	require.Equal(t, "gz", out.Zip)
+	plain, err := out.UncompressedData()
+	require.NoError(t, err)
+	require.Equal(t, data, plain)
+
+	// Changing zip invalidates the signature
+	out.Zip = ""
+	require.EqualError(t, out.Verify(), "verify failed")
+
+	// Small data isn't compressed
+	st, err = keys.NewSigchainStatementCompressed(sc, []byte("hi"), alice, "test", clock.Now())
+	require.NoError(t, err)
+	require.Equal(t, "", st.Zip)
+	plain, err = st.UncompressedData()
+	require.NoError(t, err)
+	require.Equal(t, []byte("hi"), plain)
+}
+
+func TestStatementCompressedBomb(t *testing.T) {
+	clock := tsutil.NewTestClock()
+	alice := keys.NewEdX25519KeyFromSeed(testSeed(0x01))
+
+	// Compresses to a few KB, but is larger than the max uncompressed size
+	var buf bytes.Buffer
+	w := gzip.NewWriter(&buf)
+	_, err := w.Write(make([]byte, keys.StatementZipMaxSize+1))
+	require.NoError(t, err)
+	err = w.Close()
+	require.NoError(t, err)
+	require.True(t, buf.Len() < 100*1024)
+
+	st := &keys.Statement{
+		KID:       alice.ID(),
+		Data:      buf.Bytes(),
+		Zip:       "gz",
+		Seq:       1,
+		Type:      "test",
+		Timestamp: clock.Now(),
+	}
+	err = st.Sign(alice)
+	require.NoError(t, err)
+	sc := keys.NewSigchain(alice.ID())
+	err = sc.Add(st)
+	require.NoError(t, err)
+
+	_, err = st.UncompressedData()
+	require.EqualError(t, err, "statement uncompressed data too large")
+
+	_, err = keys.NewSigchainStatementCompressed(sc, make([]byte, keys.StatementZipMaxSize+1), alice, "test", clock.Now())
+	require.EqualError(t, err, "statement data too large to compress")
+}
@@ -0,0 +1,96 @@
+package user
+
+import (
+	"github.com/pkg/errors"
+)
+
+var (
+	// ErrUserNotFound if the user URL (resource) was not found.
+	ErrUserNotFound = errors.New("user not found")
+	// ErrUserContentNotFound if the resource was found, but the signed message
+	// was missing.
+	ErrUserContentNotFound = errors.New("user content not found")
+	// ErrUserContentMismatch if the signed message was found, but it was
+	// invalid or was for a different user (kid, service or name).
+	ErrUserContentMismatch = errors.New("user content mismatch")
+	// ErrUserConnFailure if there was a (possibly) temporary network failure.
+	ErrUserConnFailure = errors.New("user connection failure")
+	// ErrUserFailure is any other failure.
+	ErrUserFailure = errors.New("user failure")
+)
+
+// UserError is an error from user verification.
+// Use errors.Cause (or errors.Is) to check the type of error, for example,
+// ErrUserNotFound or ErrUserContentMismatch.
+type UserError interface {
+	error
+	// Service name, for example, "github".
+	Service() string
+	// URL of the user (statement) we tried to verify.
+	URL() string
+	// Status of the result.
+	Status() Status
+}
+
+type userError struct {
+	cause  error
+	status Status
+	msg    string
+	usr    *User
+}
+
+func (e userError) Error() string {
+	if e.msg == "" {
+		return e.cause.Error()
+	}
+	return e.msg
+}
+
+func (e userError) Service() string {
+	if e.usr == nil {
+		return ""
+	}
+	return e.usr.Service
+}
+
+func (e userError) URL() string {
+	if e.usr == nil {
+		return ""
+	}
+	return e.usr.URL
+}
+
+func (e userError) Status() Status {
+	return e.status
+}
+
+// Cause for github.com/pkg/errors.
+func (e userError) Cause() error {
+	return e.cause
+}
+
+// Unwrap for errors.Is.
+func (e userError) Unwrap() error {
+	return e.cause
+}
+
+// NewUserError creates a UserError for a status and message.
+// Returns nil if status is StatusOK.
+func NewUserError(usr *User, status Status, msg string) UserError {
+	var cause error
+	switch status {
+	case StatusOK:
+		return nil
+	case StatusResourceNotFound:
+		cause = ErrUserNotFound
+	case StatusContentNotFound:
+		cause = ErrUserContentNotFound
+	case StatusStatementInvalid, StatusContentInvalid:
+		cause = ErrUserContentMismatch
+	case StatusConnFailure:
+		cause = ErrUserConnFailure
+	default:
+		cause = ErrUserFailure
+	}
+	return userError{cause: cause, status: status, msg: msg, usr: usr}
+}
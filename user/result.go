@@ -34,6 +34,12 @@ func (r Result) String() string {
 	return fmt.Sprintf("%s:%s;err=%s", r.Status, r.User, r.Err)
 }
 
+// UserError returns the error for the result, or nil if the Status is
+// StatusOK.
+func (r Result) UserError() UserError {
+	return NewUserError(r.User, r.Status, r.Err)
+}
+
 // IsTimestampExpired returns true if result Timestamp is older than dt.
 func (r Result) IsTimestampExpired(now time.Time, dt time.Duration) bool {
 	ts := tsutil.ParseMillis(r.Timestamp)
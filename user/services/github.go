@@ -24,6 +24,27 @@ func (s *github) ID() string {
 	return GithubID
 }
 
+// Name of the service.
+func (s *github) Name() string {
+	return GithubID
+}
+
+func (s *github) NormalizeName(name string) string {
+	return validate.Github.NormalizeName(name)
+}
+
+func (s *github) ValidateName(name string) error {
+	return validate.Github.ValidateName(name)
+}
+
+func (s *github) NormalizeURL(name string, urs string) (string, error) {
+	return validate.Github.NormalizeURL(name, urs)
+}
+
+func (s *github) ValidateURL(name string, urs string) error {
+	return validate.Github.ValidateURL(name, urs)
+}
+
 func (s *github) Request(ctx context.Context, client http.Client, usr *user.User) (user.Status, []byte, error) {
 	apiURL, err := validate.Github.APIURL(usr.Name, usr.URL)
 	if err != nil {
@@ -34,24 +55,24 @@ func (s *github) Request(ctx context.Context, client http.Client, usr *user.User
 }
 
 func (s *github) Verify(ctx context.Context, b []byte, usr *user.User) (user.Status, *Verified, error) {
+	return userService{s}.Verify(ctx, b, usr)
+}
+
+func (s *github) CheckContent(usr *user.User, b []byte) (user.Status, string, error) {
 	var gist gist
 	if err := json.Unmarshal(b, &gist); err != nil {
-		return user.StatusContentInvalid, nil, err
+		return user.StatusContentInvalid, "", err
 	}
-	gistUserName := validate.Github.NormalizeName(gist.Owner.Login)
+	gistUserName := s.NormalizeName(gist.Owner.Login)
 	if gistUserName != usr.Name {
-		return user.StatusContentInvalid, nil, errors.Errorf("invalid gist owner login %s", gist.Owner.Login)
+		return user.StatusContentInvalid, "", errors.Wrapf(user.ErrUserURLMismatch, "invalid gist owner login %s", gist.Owner.Login)
 	}
 
 	for _, f := range gist.Files {
-		status, statement, err := user.FindVerify(usr, []byte(f.Content), false)
-		if err != nil {
-			return status, nil, err
-		}
-		return status, &Verified{Statement: statement}, nil
+		return user.FindVerify(usr, []byte(f.Content), false)
 	}
 
-	return user.StatusContentInvalid, nil, errors.Errorf("no gist files")
+	return user.StatusContentInvalid, "", errors.Errorf("no gist files")
 }
 
 func (s *github) headers() []http.Header {
@@ -8,6 +8,7 @@ import (
 	"github.com/keys-pub/keys/http"
 	"github.com/keys-pub/keys/user"
 	"github.com/keys-pub/keys/user/services"
+	"github.com/pkg/errors"
 	"github.com/stretchr/testify/require"
 )
 
@@ -54,3 +55,17 @@ WgBpeqxaDOC8tGL 2rHKnD6KhZrBw8d tSPCc8sSTVh227E D.
 END MESSAGE.`
 	require.Equal(t, expected, result.Statement)
 }
+
+func TestGithubOwnerMismatch(t *testing.T) {
+	kid := keys.ID("kex1mnseg28xu6g3j4wur7hqwk8ag3fu3pmr2t5lync26xmgff0dtryqupf80c")
+	urs := "https://gist.github.com/alice/ceea0f3b675bac03425472692273cf52"
+
+	usr, err := user.New(kid, "github", "alice", urs, 1)
+	require.NoError(t, err)
+
+	b := []byte(`{"id":"ceea0f3b675bac03425472692273cf52","files":{},"owner":{"login":"mallory"}}`)
+	status, _, err := services.Github.Verify(context.TODO(), b, usr)
+	require.Equal(t, user.StatusContentInvalid, status)
+	require.EqualError(t, err, "invalid gist owner login mallory: name mismatch")
+	require.Equal(t, user.ErrUserURLMismatch, errors.Cause(err))
+}
@@ -14,6 +14,8 @@ import (
 
 type reddit struct{}
 
+var errRedditPostDeleted = errors.New("reddit post was deleted")
+
 // Reddit service.
 var Reddit = &reddit{}
 
@@ -30,7 +32,15 @@ func (s *reddit) Request(ctx context.Context, client http.Client, usr *user.User
 	if err != nil {
 		return user.StatusFailure, nil, err
 	}
-	return Request(ctx, client, apiURL, headers)
+	st, b, err := Request(ctx, client, apiURL, headers)
+	if err != nil {
+		if errHTTP, ok := errors.Cause(err).(http.Error); ok && errHTTP.StatusCode == 403 {
+			// Private (or quarantined/banned) subreddit.
+			return user.StatusResourceNotFound, nil, errors.Errorf("reddit post is private")
+		}
+		return st, nil, err
+	}
+	return st, b, nil
 }
 
 func (s *reddit) checkContent(name string, b []byte) ([]byte, error) {
@@ -49,20 +59,26 @@ func (s *reddit) checkContent(name string, b []byte) ([]byte, error) {
 	}
 
 	author := posts[0].Data.Children[0].Data.Author
+	selftext := posts[0].Data.Children[0].Data.Selftext
+	if author == "[deleted]" || selftext == "[deleted]" || selftext == "[removed]" {
+		return nil, errRedditPostDeleted
+	}
 	if name != strings.ToLower(author) {
-		return nil, errors.Errorf("invalid author %s", author)
+		return nil, errors.Wrapf(user.ErrUserURLMismatch, "invalid author %s", author)
 	}
 	subreddit := posts[0].Data.Children[0].Data.Subreddit
 	if "keyspubmsgs" != subreddit {
 		return nil, errors.Errorf("invalid subreddit %s", subreddit)
 	}
-	selftext := posts[0].Data.Children[0].Data.Selftext
 	return []byte(selftext), nil
 }
 
 func (s *reddit) Verify(ctx context.Context, b []byte, usr *user.User) (user.Status, *Verified, error) {
 	msg, err := s.checkContent(usr.Name, b)
 	if err != nil {
+		if err == errRedditPostDeleted {
+			return user.StatusContentNotFound, nil, err
+		}
 		return user.StatusContentInvalid, nil, err
 	}
 	status, statement, err := user.FindVerify(usr, msg, false)
@@ -8,6 +8,10 @@ import (
 	"github.com/pkg/errors"
 )
 
+// ErrRateLimited if the service responded with 429 (Too Many Requests).
+// The request can be retried later (the status is user.StatusConnFailure).
+var ErrRateLimited = errors.New("rate limited")
+
 // Request resource.
 func Request(ctx context.Context, client http.Client, urs string, headers []http.Header) (user.Status, []byte, error) {
 	logger.Infof("Requesting %s", urs)
@@ -17,8 +21,13 @@ func Request(ctx context.Context, client http.Client, urs string, headers []http
 	}
 	b, err := client.Request(ctx, req, headers)
 	if err != nil {
-		if errHTTP, ok := errors.Cause(err).(http.Error); ok && errHTTP.StatusCode == 404 {
-			return user.StatusResourceNotFound, nil, errors.Errorf("resource not found")
+		if errHTTP, ok := errors.Cause(err).(http.Error); ok {
+			switch errHTTP.StatusCode {
+			case 404:
+				return user.StatusResourceNotFound, nil, errors.Errorf("resource not found")
+			case 429:
+				return user.StatusConnFailure, nil, ErrRateLimited
+			}
 		}
 		return user.StatusConnFailure, nil, err
 	}
@@ -3,9 +3,11 @@ package services
 
 import (
 	"context"
+	"sync"
 
 	"github.com/keys-pub/keys/http"
 	"github.com/keys-pub/keys/user"
+	"github.com/keys-pub/keys/user/validate"
 	"github.com/pkg/errors"
 )
 
@@ -25,19 +27,75 @@ type Service interface {
 	Verify(ctx context.Context, b []byte, usr *user.User) (user.Status, *Verified, error)
 }
 
+// UserService is a user service that validates and verifies users, for
+// services other than the built-in ones, see RegisterUserService.
+type UserService interface {
+	// Name of the service, for example "github".
+	Name() string
+
+	// Validator to normalize and validate the user name and URL (ValidateURL).
+	validate.Validator
+
+	// Request resource with client.
+	Request(ctx context.Context, client http.Client, usr *user.User) (user.Status, []byte, error)
+
+	// CheckContent checks the requested content (body) has the signed
+	// statement for the user, and returns the statement.
+	CheckContent(usr *user.User, body []byte) (user.Status, string, error)
+}
+
+var servicesMtx sync.RWMutex
+
 var services = map[string]Service{
 	"twitter": Twitter,
-	"github":  Github,
 	"reddit":  Reddit,
 	"https":   HTTPS,
 	"echo":    Echo,
 }
 
+func init() {
+	RegisterUserService(Github)
+}
+
 // Lookup service by name.
 func Lookup(service string) (Service, error) {
+	servicesMtx.RLock()
+	defer servicesMtx.RUnlock()
 	out, ok := services[service]
 	if out == nil || !ok {
 		return nil, errors.Errorf("service not found: %s", service)
 	}
 	return out, nil
 }
+
+// RegisterUserService registers a user service by its Name, for validating
+// users (see validate.Lookup) and verifying them (see Lookup).
+// Registering an existing name replaces it.
+func RegisterUserService(s UserService) {
+	servicesMtx.Lock()
+	defer servicesMtx.Unlock()
+	validate.Register(s.Name(), s)
+	services[s.Name()] = userService{s}
+}
+
+// UnregisterUserService removes a service registered with
+// RegisterUserService.
+func UnregisterUserService(name string) {
+	servicesMtx.Lock()
+	defer servicesMtx.Unlock()
+	validate.Unregister(name)
+	delete(services, name)
+}
+
+// userService is a Service for a UserService.
+type userService struct {
+	UserService
+}
+
+func (s userService) Verify(ctx context.Context, b []byte, usr *user.User) (user.Status, *Verified, error) {
+	status, statement, err := s.CheckContent(usr, b)
+	if err != nil {
+		return status, nil, err
+	}
+	return status, &Verified{Statement: statement}, nil
+}
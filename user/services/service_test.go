@@ -1 +1,21 @@
 package services_test
+
+import (
+	"testing"
+
+	"github.com/keys-pub/keys/user/services"
+	"github.com/keys-pub/keys/user/validate"
+	"github.com/stretchr/testify/require"
+)
+
+func TestGithubUserService(t *testing.T) {
+	var us services.UserService = services.Github
+	require.Equal(t, "github", us.Name())
+
+	// Github is registered with RegisterUserService
+	v, err := validate.Lookup("github")
+	require.NoError(t, err)
+	require.Equal(t, services.Github, v)
+	_, err = services.Lookup("github")
+	require.NoError(t, err)
+}
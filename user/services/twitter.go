@@ -57,7 +57,7 @@ func (s *twitter) Verify(ctx context.Context, b []byte, usr *user.User) (user.St
 		if authorID == tweetUser.ID {
 			tweetUserName := validate.Twitter.NormalizeName(tweetUser.Username)
 			if tweetUserName != usr.Name {
-				return user.StatusContentInvalid, nil, errors.Errorf("invalid tweet username %s", tweetUser.Username)
+				return user.StatusContentInvalid, nil, errors.Wrapf(user.ErrUserURLMismatch, "invalid tweet username %s", tweetUser.Username)
 			}
 			found = true
 		}
@@ -192,6 +192,21 @@ func (u *User) Validate() error {
 	return nil
 }
 
+// ErrUserURLMismatch if the user URL doesn't belong to the user name, for
+// example, a github gist URL owned by someone else.
+var ErrUserURLMismatch = validate.ErrNameMismatch
+
+// ValidateUserURL checks the user URL is for the user (service) name.
+// Returns an error with cause ErrUserURLMismatch if the URL is for a different
+// name.
+func ValidateUserURL(usr *User) error {
+	service, err := validate.Lookup(usr.Service)
+	if err != nil {
+		return err
+	}
+	return service.ValidateURL(usr.Name, usr.URL)
+}
+
 // ErrUserAlreadySet is user already set in sigchain.
 var ErrUserAlreadySet = errors.New("user set in sigchain already")
 
@@ -230,6 +245,8 @@ func NewSigchainStatement(sc *keys.Sigchain, user *User, sk *keys.EdX25519Key, t
 }
 
 // Sign user into an armored message.
+// This is the proof to publish at the user URL (for example, in a gist), and
+// is what FindVerify looks for.
 func (u *User) Sign(key *keys.EdX25519Key) (string, error) {
 	b, err := u.MarshalJSON()
 	if err != nil {
@@ -8,6 +8,7 @@ import (
 	"github.com/keys-pub/keys"
 	"github.com/keys-pub/keys/tsutil"
 	"github.com/keys-pub/keys/user"
+	"github.com/pkg/errors"
 	"github.com/stretchr/testify/require"
 )
 
@@ -130,6 +131,32 @@ func TestSignUserVerify(t *testing.T) {
 	require.NoError(t, err)
 }
 
+func TestSignUserFindVerify(t *testing.T) {
+	sk := keys.NewEdX25519KeyFromSeed(testSeed(0x01))
+
+	u, err := user.NewForSigning(sk.ID(), "github", "alice")
+	require.NoError(t, err)
+	msg, err := u.Sign(sk)
+	require.NoError(t, err)
+
+	usr, err := user.New(sk.ID(), "github", "alice", "https://gist.github.com/alice/deadbeef", 1)
+	require.NoError(t, err)
+
+	// Proof pasted with surrounding content
+	content := "My keys.pub proof:\n\n" + msg + "\n"
+	status, out, err := user.FindVerify(usr, []byte(content), false)
+	require.NoError(t, err)
+	require.Equal(t, user.StatusOK, status)
+	require.Equal(t, msg, out)
+
+	// Proof for a different name
+	usr2, err := user.New(sk.ID(), "github", "bob", "https://gist.github.com/bob/deadbeef", 1)
+	require.NoError(t, err)
+	status, _, err = user.FindVerify(usr2, []byte(content), false)
+	require.EqualError(t, err, "failed to user verify: name mismatch bob != alice")
+	require.Equal(t, user.StatusStatementInvalid, status)
+}
+
 func TestUserVerify(t *testing.T) {
 	msg := "BEGIN MESSAGE.HWNhu0mATP1TJvQ 2MsM6UREvrdpmJL mlr4taMzxi0olt7 nV35Vkco9gjJ3wyZ0z9hiq2OxrlFUT QVAdNgSZPX3TCKq 6Xr2MZHgg6PbuKB KKAcQRbMCMprx0eQ9AAmF37oSytfuD ekFhesy6sjWc4kJ XA4C6PAxTFwtO14 CEXTYQyBxGH2CYAsm4w2O9xq9TNTZw lo0e7ydqx99UXE8 Qivwr0VNs5.END MESSAGE."
 	usr := &user.User{
@@ -220,3 +247,72 @@ func TestNewUser(t *testing.T) {
 	require.EqualError(t, uerr, "invalid scheme for url twitter.com/gbrltest/status/1234")
 	require.Nil(t, u12)
 }
+
+func TestValidateUserURL(t *testing.T) {
+	sk := keys.NewEdX25519KeyFromSeed(testSeed(0x01))
+
+	usr := &user.User{
+		KID:     sk.ID(),
+		Service: "github",
+		Name:    "alice",
+		URL:     "https://gist.github.com/alice/deadbeef",
+		Seq:     1,
+	}
+	err := user.ValidateUserURL(usr)
+	require.NoError(t, err)
+
+	usr.URL = "https://gist.github.com/mallory/deadbeef"
+	err = user.ValidateUserURL(usr)
+	require.EqualError(t, err, "path invalid (name mismatch) mallory != alice")
+	require.Equal(t, user.ErrUserURLMismatch, errors.Cause(err))
+
+	usr = &user.User{
+		KID:     sk.ID(),
+		Service: "twitter",
+		Name:    "alice",
+		URL:     "https://twitter.com/mallory/status/1",
+		Seq:     1,
+	}
+	err = user.ValidateUserURL(usr)
+	require.Equal(t, user.ErrUserURLMismatch, errors.Cause(err))
+
+	usr = &user.User{
+		KID:     sk.ID(),
+		Service: "reddit",
+		Name:    "alice",
+		URL:     "https://reddit.com/r/keyspubmsgs/comments/f8g9vd/mallory/",
+		Seq:     1,
+	}
+	err = user.ValidateUserURL(usr)
+	require.Equal(t, user.ErrUserURLMismatch, errors.Cause(err))
+
+	// Other errors
+	usr.URL = "https://reddit.com/r/subreddit/comments/f8g9vd/alice/"
+	err = user.ValidateUserURL(usr)
+	require.EqualError(t, err, "invalid path /r/subreddit/comments/f8g9vd/alice/")
+	require.NotEqual(t, user.ErrUserURLMismatch, errors.Cause(err))
+}
+
+func TestResultUserError(t *testing.T) {
+	sk := keys.NewEdX25519KeyFromSeed(testSeed(0x01))
+	usr, err := user.New(sk.ID(), "github", "alice", "https://gist.github.com/alice/70281cc427850c272a8574af4d8564d9", 1)
+	require.NoError(t, err)
+
+	result := user.Result{User: usr, Status: user.StatusOK}
+	require.Nil(t, result.UserError())
+
+	result = user.Result{User: usr, Status: user.StatusResourceNotFound, Err: "resource not found"}
+	uerr := result.UserError()
+	require.EqualError(t, uerr, "resource not found")
+	require.Equal(t, user.ErrUserNotFound, errors.Cause(uerr))
+	require.Equal(t, "github", uerr.Service())
+	require.Equal(t, "https://gist.github.com/alice/70281cc427850c272a8574af4d8564d9", uerr.URL())
+	require.Equal(t, user.StatusResourceNotFound, uerr.Status())
+
+	result = user.Result{User: usr, Status: user.StatusStatementInvalid, Err: "failed to user verify: name mismatch alice != bob"}
+	require.Equal(t, user.ErrUserContentMismatch, errors.Cause(result.UserError()))
+
+	result = user.Result{User: usr, Status: user.StatusConnFailure}
+	require.EqualError(t, result.UserError(), "user connection failure")
+	require.Equal(t, user.ErrUserConnFailure, errors.Cause(result.UserError()))
+}
@@ -56,7 +56,7 @@ func (s *echo) ValidateURL(name string, urs string) error {
 		return errors.Errorf("path invalid %s for url %s", paths, u)
 	}
 	if paths[0] != name {
-		return errors.Errorf("path invalid (name mismatch) %s != %s", paths[0], name)
+		return nameMismatchf("path invalid (name mismatch) %s != %s", paths[0], name)
 	}
 	return nil
 }
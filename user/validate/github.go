@@ -64,7 +64,7 @@ func (s *github) APIURL(name string, urs string) (string, error) {
 		return "", errors.Errorf("path invalid %s for url %s", paths, u)
 	}
 	if paths[0] != name {
-		return "", errors.Errorf("path invalid (name mismatch) %s != %s", paths[0], name)
+		return "", nameMismatchf("path invalid (name mismatch) %s != %s", paths[0], name)
 	}
 	id := paths[1]
 	api := "https://api.github.com/gists/" + id
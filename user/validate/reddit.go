@@ -64,7 +64,10 @@ func (s *reddit) APIURL(name string, urs string) (string, error) {
 
 	prunedName := strings.ReplaceAll(name, "-", "")
 
-	if len(paths) >= 5 && paths[0] == "r" && paths[1] == "keyspubmsgs" && paths[2] == "comments" && paths[4] == prunedName {
+	if len(paths) >= 5 && paths[0] == "r" && paths[1] == "keyspubmsgs" && paths[2] == "comments" {
+		if paths[4] != prunedName {
+			return "", nameMismatchf("invalid path %s", u.Path)
+		}
 		// Request json
 		ursj, err := url.Parse("https://www.reddit.com" + strings.TrimSuffix(u.Path, "/") + ".json")
 		if err != nil {
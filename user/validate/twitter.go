@@ -86,7 +86,7 @@ func (s *twitter) APIURL(name string, urs string) (string, error) {
 		return "", err
 	}
 	if uname != name {
-		return "", errors.Errorf("path invalid (name mismatch) for url %s", urs)
+		return "", nameMismatchf("path invalid (name mismatch) for url %s", urs)
 	}
 	return "https://api.twitter.com/2/tweets/" + status + "?expansions=author_id", nil
 }
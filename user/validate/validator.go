@@ -4,6 +4,7 @@ import (
 	"fmt"
 	"net/url"
 	"regexp"
+	"sync"
 
 	"github.com/pkg/errors"
 )
@@ -25,6 +26,30 @@ type Validator interface {
 	ValidateURL(name string, urs string) error
 }
 
+// ErrNameMismatch if the name in an URL doesn't match the user name, for
+// example, a github gist URL owned by a different user.
+var ErrNameMismatch = errors.New("name mismatch")
+
+// errNameMismatch is a ErrNameMismatch with a more detailed message.
+type errNameMismatch struct {
+	msg string
+}
+
+func (e errNameMismatch) Error() string {
+	return e.msg
+}
+
+// Cause returns ErrNameMismatch.
+func (e errNameMismatch) Cause() error {
+	return ErrNameMismatch
+}
+
+func nameMismatchf(format string, args ...interface{}) error {
+	return errNameMismatch{msg: fmt.Sprintf(format, args...)}
+}
+
+var servicesMtx sync.RWMutex
+
 var services = map[string]Validator{
 	"twitter": Twitter,
 	"github":  Github,
@@ -35,6 +60,8 @@ var services = map[string]Validator{
 
 // Lookup service by name.
 func Lookup(service string) (Validator, error) {
+	servicesMtx.RLock()
+	defer servicesMtx.RUnlock()
 	out, ok := services[service]
 	if out == nil || !ok {
 		return nil, errors.Errorf("service not found: %s", service)
@@ -42,6 +69,23 @@ func Lookup(service string) (Validator, error) {
 	return out, nil
 }
 
+// Register a validator by service name.
+// This only registers validation, use services.RegisterUserService to
+// register a user service.
+// Registering an existing name replaces it.
+func Register(service string, s Validator) {
+	servicesMtx.Lock()
+	defer servicesMtx.Unlock()
+	services[service] = s
+}
+
+// Unregister a validator by service name.
+func Unregister(service string) {
+	servicesMtx.Lock()
+	defer servicesMtx.Unlock()
+	delete(services, service)
+}
+
 var regAlphaNumericWithDash = regexp.MustCompile(`^[a-z0-9-]+$`)
 var regAlphaNumericWithUnderscore = regexp.MustCompile(`^[a-z0-9_]+$`)
 var regAlphaNumericWithDashUnderscore = regexp.MustCompile(`^[a-z0-9-_]+$`)
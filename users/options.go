@@ -1,6 +1,8 @@
 package users
 
 import (
+	"time"
+
 	"github.com/keys-pub/keys/http"
 	"github.com/keys-pub/keys/tsutil"
 	"github.com/keys-pub/keys/user"
@@ -52,6 +54,12 @@ type UpdateOptions struct {
 	// Specify the service to use for the check.
 	// For twitter proxy, use services.Proxy.
 	Service ServiceLookupFn
+	// MaxAge is how old a cached OK result can be before it is checked again.
+	// If 0, the result is always checked.
+	MaxAge time.Duration
+	// FailureMaxAge is how old a cached failed result can be before it is
+	// checked again. If 0, defaults to MaxAge/10.
+	FailureMaxAge time.Duration
 }
 
 // UpdateOption ...
@@ -62,6 +70,9 @@ func newUpdateOptions(opts ...UpdateOption) UpdateOptions {
 	for _, o := range opts {
 		o(&options)
 	}
+	if options.FailureMaxAge == 0 {
+		options.FailureMaxAge = options.MaxAge / 10
+	}
 	return options
 }
 
@@ -71,3 +82,19 @@ func UseService(service ServiceLookupFn) UpdateOption {
 		o.Service = service
 	}
 }
+
+// MaxAge option, to use the cached result if it was checked within dt.
+// Use 0 to force a refresh.
+func MaxAge(dt time.Duration) UpdateOption {
+	return func(o *UpdateOptions) {
+		o.MaxAge = dt
+	}
+}
+
+// FailureMaxAge option, to use a cached failed result if it was checked
+// within dt.
+func FailureMaxAge(dt time.Duration) UpdateOption {
+	return func(o *UpdateOptions) {
+		o.FailureMaxAge = dt
+	}
+}
@@ -76,3 +76,46 @@ func TestResultReddit(t *testing.T) {
 	require.Equal(t, 1, len(res))
 	require.Equal(t, keys.ID("kex132yw8ht5p8cetl2jmvknewjawt9xwzdlrk2pyxlnwjyqrdq0dawqqph077"), res[0].KID)
 }
+
+func TestResultRedditDeleted(t *testing.T) {
+	sk := keys.NewEdX25519KeyFromSeed(testSeed(0x01))
+	clock := tsutil.NewTestClock()
+	ds := dstore.NewMem()
+	scs := keys.NewSigchains(ds)
+	usrs := users.New(ds, scs, users.Clock(clock))
+
+	sc := keys.NewSigchain(sk.ID())
+	stu, err := user.New(sk.ID(), "reddit", "charlie", "https://www.reddit.com/r/keyspubmsgs/comments/f8g9vd/charlie/", sc.LastSeq()+1)
+	require.NoError(t, err)
+	st, err := user.NewSigchainStatement(sc, stu, sk, clock.Now())
+	require.NoError(t, err)
+	err = sc.Add(st)
+	require.NoError(t, err)
+	err = scs.Save(sc)
+	require.NoError(t, err)
+
+	deleted := `[{"kind":"Listing","data":{"children":[{"kind":"t3","data":{"subreddit":"keyspubmsgs","selftext":"[deleted]","author":"[deleted]"}}]}}]`
+	usrs.Client().SetProxy("", func(ctx context.Context, req *http.Request, headers []http.Header) http.ProxyResponse {
+		return http.ProxyResponse{Body: []byte(deleted)}
+	})
+	result, err := usrs.Update(context.TODO(), sk.ID())
+	require.NoError(t, err)
+	require.Equal(t, user.StatusContentNotFound, result.Status)
+	require.Equal(t, "reddit post was deleted", result.Err)
+
+	removed := `[{"kind":"Listing","data":{"children":[{"kind":"t3","data":{"subreddit":"keyspubmsgs","selftext":"[removed]","author":"charlie"}}]}}]`
+	usrs.Client().SetProxy("", func(ctx context.Context, req *http.Request, headers []http.Header) http.ProxyResponse {
+		return http.ProxyResponse{Body: []byte(removed)}
+	})
+	result, err = usrs.Update(context.TODO(), sk.ID())
+	require.NoError(t, err)
+	require.Equal(t, user.StatusContentNotFound, result.Status)
+
+	usrs.Client().SetProxy("", func(ctx context.Context, req *http.Request, headers []http.Header) http.ProxyResponse {
+		return http.ProxyResponse{Err: http.Error{StatusCode: 403}}
+	})
+	result, err = usrs.Update(context.TODO(), sk.ID())
+	require.NoError(t, err)
+	require.Equal(t, user.StatusResourceNotFound, result.Status)
+	require.Equal(t, "reddit post is private", result.Err)
+}
@@ -0,0 +1,86 @@
+package users_test
+
+import (
+	"context"
+	"testing"
+
+	"github.com/keys-pub/keys"
+	"github.com/keys-pub/keys/dstore"
+	"github.com/keys-pub/keys/http"
+	"github.com/keys-pub/keys/tsutil"
+	"github.com/keys-pub/keys/user"
+	"github.com/keys-pub/keys/user/services"
+	"github.com/keys-pub/keys/users"
+	"github.com/pkg/errors"
+	"github.com/stretchr/testify/require"
+)
+
+type exampleService struct{}
+
+func (s exampleService) Name() string { return "example" }
+
+func (s exampleService) NormalizeName(name string) string { return name }
+
+func (s exampleService) ValidateName(name string) error { return nil }
+
+func (s exampleService) NormalizeURL(name string, urs string) (string, error) { return urs, nil }
+
+func (s exampleService) ValidateURL(name string, urs string) error {
+	if urs != "https://example.com/"+name+"/proof" {
+		return errors.Errorf("invalid url %s", urs)
+	}
+	return nil
+}
+
+func (s exampleService) Request(ctx context.Context, client http.Client, usr *user.User) (user.Status, []byte, error) {
+	return services.Request(ctx, client, usr.URL, nil)
+}
+
+func (s exampleService) CheckContent(usr *user.User, b []byte) (user.Status, string, error) {
+	return user.FindVerify(usr, b, false)
+}
+
+func TestRegisterService(t *testing.T) {
+	sk := keys.NewEdX25519KeyFromSeed(testSeed(0x01))
+	clock := tsutil.NewTestClock()
+	ds := dstore.NewMem()
+	scs := keys.NewSigchains(ds)
+	usrs := users.New(ds, scs, users.Clock(clock))
+
+	_, err := user.New(sk.ID(), "example", "alice", "https://example.com/alice/proof", 1)
+	require.EqualError(t, err, "service not found: example")
+
+	services.RegisterUserService(exampleService{})
+	defer services.UnregisterUserService("example")
+
+	_, err = user.New(sk.ID(), "example", "alice", "https://example.com/bob/proof", 1)
+	require.EqualError(t, err, "invalid url https://example.com/bob/proof")
+
+	sc := keys.NewSigchain(sk.ID())
+	usr, err := user.New(sk.ID(), "example", "alice", "https://example.com/alice/proof", sc.LastSeq()+1)
+	require.NoError(t, err)
+	msg, err := usr.Sign(sk)
+	require.NoError(t, err)
+	st, err := user.NewSigchainStatement(sc, usr, sk, clock.Now())
+	require.NoError(t, err)
+	err = sc.Add(st)
+	require.NoError(t, err)
+	err = scs.Save(sc)
+	require.NoError(t, err)
+
+	usrs.Client().SetProxy("https://example.com/alice/proof", func(ctx context.Context, req *http.Request, headers []http.Header) http.ProxyResponse {
+		return http.ProxyResponse{Body: []byte(msg)}
+	})
+
+	result, err := usrs.Update(context.TODO(), sk.ID())
+	require.NoError(t, err)
+	require.Equal(t, user.StatusOK, result.Status)
+	require.Equal(t, "example", result.User.Service)
+	require.Equal(t, "alice", result.User.Name)
+
+	services.UnregisterUserService("example")
+	_, err = user.New(sk.ID(), "example", "alice", "https://example.com/alice/proof", 1)
+	require.EqualError(t, err, "service not found: example")
+	_, err = services.Lookup("example")
+	require.EqualError(t, err, "service not found: example")
+}
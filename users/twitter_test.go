@@ -4,6 +4,7 @@ import (
 	"context"
 	"encoding/json"
 	"testing"
+	"time"
 
 	"github.com/keys-pub/keys"
 	"github.com/keys-pub/keys/dstore"
@@ -196,3 +197,105 @@ func TestResultTwitterProxy(t *testing.T) {
 	// require.NotNil(t, result)
 	// require.Equal(t, user.StatusOK, result.Status)
 }
+
+func TestResultTwitterRateLimited(t *testing.T) {
+	sk := keys.NewEdX25519KeyFromSeed(testSeed(0x01))
+	clock := tsutil.NewTestClock()
+	ds := dstore.NewMem()
+	scs := keys.NewSigchains(ds)
+	usrs := users.New(ds, scs, users.Clock(clock))
+
+	sc := keys.NewSigchain(sk.ID())
+	stu, err := user.New(sk.ID(), "twitter", "bob", "https://twitter.com/bob/status/1205589994380783616", sc.LastSeq()+1)
+	require.NoError(t, err)
+	st, err := user.NewSigchainStatement(sc, stu, sk, clock.Now())
+	require.NoError(t, err)
+	err = sc.Add(st)
+	require.NoError(t, err)
+	err = scs.Save(sc)
+	require.NoError(t, err)
+
+	usrs.Client().SetProxy("", func(ctx context.Context, req *http.Request, headers []http.Header) http.ProxyResponse {
+		return http.ProxyResponse{Err: http.Error{StatusCode: 429}}
+	})
+	result, err := usrs.Update(context.TODO(), sk.ID())
+	require.NoError(t, err)
+	require.Equal(t, user.StatusConnFailure, result.Status)
+	require.Equal(t, services.ErrRateLimited.Error(), result.Err)
+
+	usrs.Client().SetProxy("", func(ctx context.Context, req *http.Request, headers []http.Header) http.ProxyResponse {
+		return http.ProxyResponse{Err: http.Error{StatusCode: 404}}
+	})
+	result, err = usrs.Update(context.TODO(), sk.ID())
+	require.NoError(t, err)
+	require.Equal(t, user.StatusResourceNotFound, result.Status)
+	require.Equal(t, "resource not found", result.Err)
+}
+
+func TestResultTwitterMaxAge(t *testing.T) {
+	sk := keys.NewEdX25519KeyFromSeed(testSeed(0x01))
+
+	clock := tsutil.NewTestClock()
+
+	ds := dstore.NewMem()
+	scs := keys.NewSigchains(ds)
+	usrs := users.New(ds, scs, users.Clock(clock))
+
+	sc := keys.NewSigchain(sk.ID())
+	stu, err := user.New(sk.ID(), "twitter", "bob", "https://twitter.com/bob/status/1205589994380783616", sc.LastSeq()+1)
+	require.NoError(t, err)
+	st, err := user.NewSigchainStatement(sc, stu, sk, clock.Now())
+	require.NoError(t, err)
+	err = sc.Add(st)
+	require.NoError(t, err)
+	err = scs.Save(sc)
+	require.NoError(t, err)
+
+	requests := 0
+	usrs.Client().SetProxy("", func(ctx context.Context, req *http.Request, headers []http.Header) http.ProxyResponse {
+		requests++
+		return http.ProxyResponse{Body: testdata(t, "testdata/twitter/1205589994380783616.json")}
+	})
+
+	result, err := usrs.Update(context.TODO(), sk.ID(), users.MaxAge(time.Hour))
+	require.NoError(t, err)
+	require.Equal(t, user.StatusOK, result.Status)
+	require.Equal(t, 1, requests)
+
+	// Within MaxAge uses cached result
+	result, err = usrs.Update(context.TODO(), sk.ID(), users.MaxAge(time.Hour))
+	require.NoError(t, err)
+	require.Equal(t, user.StatusOK, result.Status)
+	require.Equal(t, 1, requests)
+
+	// Force refresh
+	_, err = usrs.Update(context.TODO(), sk.ID())
+	require.NoError(t, err)
+	require.Equal(t, 2, requests)
+
+	// After MaxAge
+	clock.Add(time.Hour)
+	_, err = usrs.Update(context.TODO(), sk.ID(), users.MaxAge(time.Hour))
+	require.NoError(t, err)
+	require.Equal(t, 3, requests)
+
+	// Failures are cached for less time (MaxAge/10)
+	usrs.Client().SetProxy("", func(ctx context.Context, req *http.Request, headers []http.Header) http.ProxyResponse {
+		requests++
+		return http.ProxyResponse{Err: errors.Errorf("testing")}
+	})
+	result, err = usrs.Update(context.TODO(), sk.ID())
+	require.NoError(t, err)
+	require.Equal(t, user.StatusConnFailure, result.Status)
+	require.Equal(t, 4, requests)
+
+	result, err = usrs.Update(context.TODO(), sk.ID(), users.MaxAge(time.Hour))
+	require.NoError(t, err)
+	require.Equal(t, user.StatusConnFailure, result.Status)
+	require.Equal(t, 4, requests)
+
+	clock.Add(time.Minute * 10)
+	_, err = usrs.Update(context.TODO(), sk.ID(), users.MaxAge(time.Hour))
+	require.NoError(t, err)
+	require.Equal(t, 5, requests)
+}
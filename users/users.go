@@ -73,6 +73,8 @@ func (u *Users) Update(ctx context.Context, kid keys.ID, opt ...UpdateOption) (*
 
 // CheckSigchain looks for user in a Sigchain and creates a result or updates
 // the current result.
+// If MaxAge is specified and the current result was checked recently, it is
+// returned without making a request.
 func (u *Users) CheckSigchain(ctx context.Context, sc *keys.Sigchain, opt ...UpdateOption) (*user.Result, error) {
 	usr, err := user.FindInSigchain(sc)
 	if err != nil {
@@ -93,6 +95,13 @@ func (u *Users) CheckSigchain(ctx context.Context, sc *keys.Sigchain, opt ...Upd
 	if result == nil {
 		result = &user.Result{}
 	}
+
+	opts := newUpdateOptions(opt...)
+	if isResultCached(result, usr, opts, u.opts.Clock) {
+		logger.Debugf("Using cached result for %s", usr.KID)
+		return result, nil
+	}
+
 	// Set or update user (in case user changed)
 	result.User = usr
 
@@ -106,6 +115,22 @@ func (u *Users) CheckSigchain(ctx context.Context, sc *keys.Sigchain, opt ...Upd
 	return result, nil
 }
 
+// isResultCached returns true if result is for the same user and was checked
+// recently enough (see MaxAge, FailureMaxAge).
+func isResultCached(result *user.Result, usr *user.User, opts UpdateOptions, clock tsutil.Clock) bool {
+	if result.User == nil || *result.User != *usr || result.Timestamp == 0 {
+		return false
+	}
+	dt := opts.MaxAge
+	if result.Status != user.StatusOK {
+		dt = opts.FailureMaxAge
+	}
+	if dt <= 0 {
+		return false
+	}
+	return !result.IsTimestampExpired(clock.Now(), dt)
+}
+
 // RequestVerify requests and verifies a user. Doesn't index result.
 func (u *Users) RequestVerify(ctx context.Context, service services.Service, usr *user.User) *user.Result {
 	result := &user.Result{
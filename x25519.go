@@ -2,6 +2,7 @@ package keys
 
 import (
 	"bytes"
+	"crypto/subtle"
 
 	"github.com/pkg/errors"
 	"golang.org/x/crypto/curve25519"
@@ -60,6 +61,11 @@ func (k *X25519Key) PublicKey() *X25519PublicKey {
 	return k.publicKey
 }
 
+// Equal returns true if equal to key (in constant time).
+func (k *X25519Key) Equal(o *X25519Key) bool {
+	return subtle.ConstantTimeCompare(k.privateKey[:], o.privateKey[:]) == 1
+}
+
 // GenerateX25519Key creates a new X25519Key.
 func GenerateX25519Key() *X25519Key {
 	logger.Infof("Generating X25519 key...")
@@ -169,3 +175,8 @@ func (k *X25519PublicKey) Bytes32() *[32]byte {
 func (k *X25519PublicKey) Private() []byte {
 	return nil
 }
+
+// Equal returns true if equal to key (in constant time).
+func (k *X25519PublicKey) Equal(o *X25519PublicKey) bool {
+	return subtle.ConstantTimeCompare(k.publicKey[:], o.publicKey[:]) == 1
+}
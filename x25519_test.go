@@ -5,6 +5,7 @@ import (
 	"testing"
 
 	"github.com/keys-pub/keys"
+	"github.com/keys-pub/keys/encoding"
 	"github.com/stretchr/testify/require"
 )
 
@@ -26,7 +27,35 @@ func TestX25519KeyConversion(t *testing.T) {
 	require.Equal(t, bk.PublicKey().Bytes()[:], bpk.Bytes()[:])
 }
 
+func TestX25519KeyConversionSodium(t *testing.T) {
+	// Test vector from libsodium test/default/ed25519_convert.c
+	seed := encoding.MustDecode("421151a459faeade3d247115f94aedae42318124095afabe4d1451a559faedee", encoding.Hex)
+	sk := keys.NewEdX25519KeyFromSeed(keys.Bytes32(seed))
+
+	// crypto_sign_ed25519_pk_to_curve25519
+	expectedPublic := encoding.MustDecode("f1814f0e8ff1043d8a44d25babff3cedcae6c22c3edaa48f857ae70de2baae50", encoding.Hex)
+	require.Equal(t, expectedPublic, sk.PublicKey().X25519PublicKey().Bytes())
+	require.Equal(t, expectedPublic, sk.X25519Key().PublicKey().Bytes())
+
+	// crypto_sign_ed25519_sk_to_curve25519 (clamped)
+	expectedPrivate := encoding.MustDecode("8052030376d47112be7f73ed7a019293dd12ad910b654455798b4667d73de166", encoding.Hex)
+	priv := append([]byte{}, sk.X25519Key().Private()...)
+	priv[0] &= 248
+	priv[31] &= 127
+	priv[31] |= 64
+	require.Equal(t, expectedPrivate, priv)
+}
+
 func ExampleGenerateX25519Key() {
 	alice := keys.GenerateX25519Key()
 	fmt.Printf("Alice: %s\n", alice.ID())
 }
+
+func TestX25519KeyEqual(t *testing.T) {
+	alice := keys.NewX25519KeyFromSeed(testSeed(0x01))
+	bob := keys.NewX25519KeyFromSeed(testSeed(0x02))
+	require.True(t, alice.Equal(keys.NewX25519KeyFromSeed(testSeed(0x01))))
+	require.False(t, alice.Equal(bob))
+	require.True(t, alice.PublicKey().Equal(keys.NewX25519KeyFromSeed(testSeed(0x01)).PublicKey()))
+	require.False(t, alice.PublicKey().Equal(bob.PublicKey()))
+}